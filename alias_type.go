@@ -32,5 +32,5 @@ func (a *aliasType) Generate(resolver *ParameterResolver) (interface{}, error) {
 		return r.Generate(resolver)
 	}
 
-	return resolver.Container.Get(a.typeID)
+	return resolver.Get(a.typeID)
 }
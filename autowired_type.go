@@ -0,0 +1,246 @@
+package goldi
+
+import (
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+)
+
+// A typedTypeFactory knows the type of the instances it generates without generating an instance.
+// This is used to find candidates when autowiring types (see NewAutowiredType).
+type typedTypeFactory interface {
+	generatedType() reflect.Type
+}
+
+func (t *typeFactory) generatedType() reflect.Type {
+	return t.factoryType.Out(0)
+}
+
+func (t *structType) generatedType() reflect.Type {
+	return reflect.PointerTo(t.structType)
+}
+
+func (t *instanceType) generatedType() reflect.Type {
+	return reflect.TypeOf(t.Instance)
+}
+
+func (t *funcType) generatedType() reflect.Type {
+	return reflect.TypeOf(t.function)
+}
+
+func (t *autowiredType) generatedType() reflect.Type {
+	return reflect.TypeOf(t.factory).Out(0)
+}
+
+// GeneratedTypeOf returns the type of the instances the given TypeFactory generates or nil if the type can not be
+// determined without generating an instance (e.g. for aliases or proxy types).
+func GeneratedTypeOf(t TypeFactory) reflect.Type {
+	for t != nil {
+		if typed, isTyped := t.(typedTypeFactory); isTyped {
+			return typed.generatedType()
+		}
+
+		wrapped, isWrapped := t.(wrappedTypeFactory)
+		if isWrapped == false {
+			break
+		}
+
+		t = wrapped.unwrap()
+	}
+
+	return nil
+}
+
+type autowiredType struct {
+	factory   interface{}
+	arguments []interface{} // the explicitly given arguments where nil means that the argument is autowired
+}
+
+// NewAutowiredType creates a TypeFactory that resolves the arguments of a factory function by their type.
+//
+// For each argument of the factory function the container searches the one registered type whose generated type
+// is assignable to the argument type. Explicit factoryParameters can be given to override individual positions.
+// All positions that are nil or not given at all are autowired. If the first argument of the factory function is a
+// context.Context, the context is injected just like with NewType.
+//
+// If no registered type or more than one type is assignable to an argument, Generate returns an error.
+// Aliases and decorators are never considered as candidates. Types whose generated type can not be determined
+// without generating them (e.g. proxy types) can not be autowired and must be referenced explicitly.
+//
+// NewAutowiredType will return an invalid type if the factoryFunction would be rejected by NewType, is variadic
+// or more factoryParameters than arguments of the factoryFunction are given.
+//
+// Goldigen yaml syntax example:
+//
+//	my_service:
+//	    package:  github.com/fgrosse/foobar
+//	    factory:  NewService
+//	    autowire: true
+//	    args:     [ ~, "%service.name%" ] # the first argument is autowired
+func NewAutowiredType(factoryFunction interface{}, factoryParameters ...interface{}) TypeFactory {
+	if factoryFunction == nil {
+		return newInvalidType(fmt.Errorf("the given factoryFunction is nil"))
+	}
+
+	factoryType := reflect.TypeOf(factoryFunction)
+	if factoryType.Kind() != reflect.Func {
+		return newInvalidType(fmt.Errorf("the given factoryFunction must be a function (given %q)", factoryType.Kind()))
+	}
+
+	if factoryType.IsVariadic() {
+		return newInvalidType(fmt.Errorf("can not autowire the variadic function %v", factoryType))
+	}
+
+	numIn := factoryType.NumIn()
+	if len(factoryParameters) > numIn {
+		return newInvalidType(fmt.Errorf("invalid number of input parameters: got %d but expected at most %d", len(factoryParameters), numIn))
+	}
+
+	t := &autowiredType{
+		factory:   factoryFunction,
+		arguments: make([]interface{}, numIn),
+	}
+	copy(t.arguments, factoryParameters)
+
+	// check the factory function and the explicit arguments by creating a type with placeholders for the autowired arguments
+	if placeholder := NewType(factoryFunction, t.placeholderArguments(factoryType)...); IsValid(placeholder) == false {
+		return placeholder
+	}
+
+	return t
+}
+
+func (t *autowiredType) placeholderArguments(factoryType reflect.Type) []interface{} {
+	args := make([]interface{}, 0, len(t.arguments))
+	for i, argument := range t.arguments {
+		switch {
+		case argument != nil:
+			args = append(args, argument)
+		case i == 0 && factoryType.In(0) == contextType:
+			// omitted so the context is injected
+		default:
+			args = append(args, "@autowired")
+		}
+	}
+
+	return args
+}
+
+// Arguments returns all explicitly given arguments.
+func (t *autowiredType) Arguments() []interface{} {
+	var args []interface{}
+	for _, argument := range t.arguments {
+		if argument != nil {
+			args = append(args, argument)
+		}
+	}
+
+	return args
+}
+
+// autowiredArgumentTypes returns the types of all arguments that are autowired.
+func (t *autowiredType) autowiredArgumentTypes() []reflect.Type {
+	factoryType := reflect.TypeOf(t.factory)
+
+	var types []reflect.Type
+	for i, argument := range t.arguments {
+		if argument == nil && (i > 0 || factoryType.In(0) != contextType) {
+			types = append(types, factoryType.In(i))
+		}
+	}
+
+	return types
+}
+
+// Generate resolves the arguments that are autowired and generates the type just like a type from NewType.
+func (t *autowiredType) Generate(resolver *ParameterResolver) (interface{}, error) {
+	factoryType := reflect.TypeOf(t.factory)
+
+	args := make([]interface{}, 0, len(t.arguments))
+	for i, argument := range t.arguments {
+		switch {
+		case argument != nil:
+			args = append(args, argument)
+		case i == 0 && factoryType.In(0) == contextType:
+			// omitted so the context is injected
+		default:
+			typeID, err := t.candidate(resolver.Container, factoryType.In(i))
+			if err != nil {
+				return nil, fmt.Errorf("could not autowire argument %d of %v: %w", i+1, factoryType, err)
+			}
+
+			args = append(args, "@"+typeID)
+		}
+	}
+
+	return NewType(t.factory, args...).Generate(resolver)
+}
+
+// candidate returns the ID of the only type that generates instances which are assignable to the expected type.
+func (t *autowiredType) candidate(container *Container, expectedType reflect.Type) (string, error) {
+	candidates := container.autowireCandidates(expectedType)
+	switch len(candidates) {
+	case 0:
+		return "", fmt.Errorf("no registered type is assignable to %v", expectedType)
+	case 1:
+		return candidates[0], nil
+	default:
+		return "", fmt.Errorf("%d registered types are assignable to %v (%s)", len(candidates), expectedType, strings.Join(candidates, ", "))
+	}
+}
+
+// autowireCandidates returns the sorted IDs of all types of the container and its parents that generate instances
+// which are assignable to the expected type.
+func (c *Container) autowireCandidates(expectedType reflect.Type) []string {
+	var candidates []string
+	seen := map[string]bool{}
+	for container := c; container != nil; container = container.parent {
+		container.readTypes(func(types TypeRegistry) {
+			for typeID, factory := range types {
+				if seen[typeID] {
+					// the type has been overwritten by a scope
+					continue
+				}
+
+				seen[typeID] = true
+				if isAutowireCandidate(factory, expectedType) {
+					candidates = append(candidates, typeID)
+				}
+			}
+		})
+	}
+
+	slices.Sort(candidates)
+	return candidates
+}
+
+// AutowireCandidates returns the sorted IDs of all types of the registry that can be injected into an autowired
+// argument of the given type (see NewAutowiredType). An autowired argument can only be resolved if there is exactly
+// one candidate.
+func (r TypeRegistry) AutowireCandidates(expectedType reflect.Type) []string {
+	var candidates []string
+	for typeID, factory := range r {
+		if isAutowireCandidate(factory, expectedType) {
+			candidates = append(candidates, typeID)
+		}
+	}
+
+	slices.Sort(candidates)
+	return candidates
+}
+
+// isAutowireCandidate returns whether the given type factory generates instances that are assignable to the
+// expected type. Aliases and decorators are never candidates.
+func isAutowireCandidate(factory TypeFactory, expectedType reflect.Type) bool {
+	if _, isAlias := factory.(*aliasType); isAlias {
+		return false
+	}
+
+	if _, isDecorator := findEmbedded[*decoratorType](factory); isDecorator {
+		return false
+	}
+
+	generatedType := GeneratedTypeOf(factory)
+	return generatedType != nil && generatedType.AssignableTo(expectedType)
+}
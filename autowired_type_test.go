@@ -0,0 +1,213 @@
+package goldi_test
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/tarokamikaze/goldi"
+)
+
+// AutowiredService is a test type whose dependencies are autowired
+type AutowiredService struct {
+	Ctx    context.Context
+	Logger Logger
+	Mock   *MockType
+	Name   string
+}
+
+func NewAutowiredService(logger Logger, mock *MockType, name string) *AutowiredService {
+	return &AutowiredService{Logger: logger, Mock: mock, Name: name}
+}
+
+func NewAutowiredServiceWithContext(ctx context.Context, logger Logger) (*AutowiredService, error) {
+	return &AutowiredService{Ctx: ctx, Logger: logger}, nil
+}
+
+// AutowiredRepository and AutowiredHandler form a chain of autowired types in the tests
+type AutowiredRepository struct {
+	Mock *MockType
+}
+
+type AutowiredHandler struct {
+	Service *AutowiredService
+}
+
+func ExampleNewAutowiredType() {
+	container := goldi.NewContainer(goldi.NewTypeRegistry(), map[string]interface{}{})
+
+	container.Register("logger", goldi.NewStructType(SimpleLogger{}))
+	container.Register("mock", goldi.NewType(NewMockType))
+
+	// the logger and the mock type are autowired, only the name is given explicitly
+	container.Register("service", goldi.NewAutowiredType(NewAutowiredService, nil, nil, "My service"))
+
+	service := container.MustGet("service").(*AutowiredService)
+	fmt.Printf("%T %T %s", service.Logger, service.Mock, service.Name)
+	// Output:
+	// *goldi_test.SimpleLogger *goldi_test.MockType My service
+}
+
+var _ = Describe("autowiredType", func() {
+	It("should implement the TypeFactory interface", func() {
+		var factory goldi.TypeFactory
+		factory = goldi.NewAutowiredType(NewAutowiredService)
+		// if this compiles the test passes (next expectation only to make compiler happy)
+		Expect(factory).NotTo(BeNil())
+	})
+
+	Describe("NewAutowiredType()", func() {
+		It("should return an invalid type if the factory is nil or no function", func() {
+			Expect(goldi.IsValid(goldi.NewAutowiredType(nil))).To(BeFalse())
+			Expect(goldi.IsValid(goldi.NewAutowiredType(42))).To(BeFalse())
+		})
+
+		It("should return an invalid type if the factory function would be rejected by NewType", func() {
+			Expect(goldi.IsValid(goldi.NewAutowiredType(func(l Logger) {}))).To(BeFalse())
+		})
+
+		It("should return an invalid type if the factory function is variadic", func() {
+			Expect(goldi.IsValid(goldi.NewAutowiredType(NewVariadicRouter))).To(BeFalse())
+		})
+
+		It("should return an invalid type if too many arguments are given", func() {
+			t := goldi.NewAutowiredType(NewAutowiredService, nil, nil, "foo", "bar")
+			Expect(goldi.IsValid(t)).To(BeFalse())
+			Expect(t).To(MatchError("invalid number of input parameters: got 4 but expected at most 3"))
+		})
+
+		It("should return an invalid type if an explicit argument has the wrong type", func() {
+			Expect(goldi.IsValid(goldi.NewAutowiredType(NewAutowiredService, nil, "foo"))).To(BeFalse())
+		})
+	})
+
+	Describe("Arguments()", func() {
+		It("should return the explicitly given arguments", func() {
+			t := goldi.NewAutowiredType(NewAutowiredService, "@logger", nil, "%name%")
+			Expect(t.Arguments()).To(Equal([]interface{}{"@logger", "%name%"}))
+		})
+	})
+
+	Describe("Generate()", func() {
+		var (
+			registry  goldi.TypeRegistry
+			container *goldi.Container
+		)
+
+		BeforeEach(func() {
+			registry = goldi.NewTypeRegistry()
+			container = goldi.NewContainer(registry, map[string]interface{}{"name": "foo"})
+			registry.Register("logger", goldi.NewStructType(SimpleLogger{}))
+			registry.RegisterType("mock", NewMockType)
+		})
+
+		It("should resolve all arguments by their type", func() {
+			registry.Register("service", goldi.NewAutowiredType(func(l Logger, m *MockType) *AutowiredService {
+				return &AutowiredService{Logger: l, Mock: m}
+			}))
+
+			service := container.MustGet("service").(*AutowiredService)
+			Expect(service.Logger).To(BeIdenticalTo(container.MustGet("logger")))
+			Expect(service.Mock).To(BeIdenticalTo(container.MustGet("mock")))
+		})
+
+		It("should use the explicitly given arguments", func() {
+			registry.Register("other_logger", goldi.NewStructType(SimpleLogger{}, "other"))
+			registry.Register("service", goldi.NewAutowiredType(NewAutowiredService, "@other_logger", nil, "%name%"))
+
+			service := container.MustGet("service").(*AutowiredService)
+			Expect(service.Logger).To(BeIdenticalTo(container.MustGet("other_logger")))
+			Expect(service.Mock).To(BeIdenticalTo(container.MustGet("mock")))
+			Expect(service.Name).To(Equal("foo"))
+		})
+
+		It("should inject the context", func() {
+			ctx := context.WithValue(context.Background(), contextKey("foo"), "bar")
+			registry.Register("service", goldi.NewAutowiredType(NewAutowiredServiceWithContext))
+
+			service, err := container.GetContext(ctx, "service")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(service.(*AutowiredService).Ctx).To(BeIdenticalTo(ctx))
+			Expect(service.(*AutowiredService).Logger).To(BeIdenticalTo(container.MustGet("logger")))
+		})
+
+		It("should not consider aliases and decorators as candidates", func() {
+			registry.Register("logger_alias", goldi.NewAliasType("logger"))
+			registry.Register("logger_decorator", goldi.NewDecoratorType(goldi.NewType(NewPrefixLogger, "@.inner", "foo"), "logger", 0))
+			registry.Register("service", goldi.NewAutowiredType(NewAutowiredService, nil, nil, "foo"))
+
+			service := container.MustGet("service").(*AutowiredService)
+			Expect(chainOf(service.Logger)).To(Equal([]string{"foo"}))
+		})
+
+		It("should consider autowired types as candidates", func() {
+			registry.Register("repository", goldi.NewAutowiredType(func(m *MockType) *AutowiredRepository {
+				return &AutowiredRepository{Mock: m}
+			}))
+			registry.Register("service", goldi.NewAutowiredType(func(r *AutowiredRepository) *AutowiredService {
+				return &AutowiredService{Mock: r.Mock}
+			}))
+			registry.Register("handler", goldi.NewAutowiredType(func(s *AutowiredService) *AutowiredHandler {
+				return &AutowiredHandler{Service: s}
+			}))
+
+			handler := container.MustGet("handler").(*AutowiredHandler)
+			Expect(handler.Service).To(BeIdenticalTo(container.MustGet("service")))
+			Expect(handler.Service.Mock).To(BeIdenticalTo(container.MustGet("mock")))
+		})
+
+		It("should consider the types of parent containers", func() {
+			scope := container.NewScope()
+			scope.Register("service", goldi.NewAutowiredType(NewAutowiredService, nil, nil, "foo"))
+
+			service := scope.MustGet("service").(*AutowiredService)
+			Expect(service.Mock).To(BeIdenticalTo(container.MustGet("mock")))
+		})
+
+		It("should return an error if no type is assignable to an argument", func() {
+			registry.Register("service", goldi.NewAutowiredType(func(db Database) *AutowiredService { return nil }))
+
+			_, err := container.Get("service")
+			Expect(err).To(MatchError(`goldi: error while generating type "service": could not autowire argument 1 of func(goldi_test.Database) *goldi_test.AutowiredService: no registered type is assignable to goldi_test.Database`))
+		})
+
+		It("should return an error if more than one type is assignable to an argument", func() {
+			registry.RegisterType("mock2", NewMockType)
+			registry.Register("service", goldi.NewAutowiredType(NewAutowiredService, nil, nil, "foo"))
+
+			_, err := container.Get("service")
+			Expect(err).To(MatchError(ContainSubstring(`could not autowire argument 2 of func(goldi_test.Logger, *goldi_test.MockType, string) *goldi_test.AutowiredService: 2 registered types are assignable to *goldi_test.MockType (mock, mock2)`)))
+		})
+	})
+
+	Describe("AutowireCandidates()", func() {
+		It("should return the sorted IDs of all types that are assignable to the given type", func() {
+			registry := goldi.NewTypeRegistry()
+			registry.RegisterType("mock2", NewMockType)
+			registry.RegisterType("mock", NewMockType)
+			registry.Register("mock_alias", goldi.NewAliasType("mock"))
+			registry.Register("logger", goldi.NewStructType(SimpleLogger{}))
+
+			Expect(registry.AutowireCandidates(reflect.TypeOf(&MockType{}))).To(Equal([]string{"mock", "mock2"}))
+			Expect(registry.AutowireCandidates(reflect.TypeOf((*Logger)(nil)).Elem())).To(Equal([]string{"logger"}))
+			Expect(registry.AutowireCandidates(reflect.TypeOf((*Database)(nil)).Elem())).To(BeEmpty())
+		})
+	})
+
+	Describe("GeneratedTypeOf()", func() {
+		It("should return the type that is generated by a type factory", func() {
+			Expect(goldi.GeneratedTypeOf(goldi.NewType(NewMockType))).To(Equal(reflect.TypeOf(&MockType{})))
+			Expect(goldi.GeneratedTypeOf(goldi.NewStructType(MockType{}))).To(Equal(reflect.TypeOf(&MockType{})))
+			Expect(goldi.GeneratedTypeOf(goldi.NewInstanceType(&SimpleLogger{}))).To(Equal(reflect.TypeOf(&SimpleLogger{})))
+			Expect(goldi.GeneratedTypeOf(goldi.NewScopedType(goldi.NewType(NewMockType), goldi.Prototype))).To(Equal(reflect.TypeOf(&MockType{})))
+			Expect(goldi.GeneratedTypeOf(goldi.NewAutowiredType(NewAutowiredService))).To(Equal(reflect.TypeOf(&AutowiredService{})))
+		})
+
+		It("should return nil if the generated type is unknown", func() {
+			Expect(goldi.GeneratedTypeOf(goldi.NewAliasType("foo"))).To(BeNil())
+			Expect(goldi.GeneratedTypeOf(goldi.NewProxyType("foo", "Bar"))).To(BeNil())
+		})
+	})
+})
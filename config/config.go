@@ -0,0 +1,134 @@
+// Package config builds the parameters of a goldi container from layered configuration sources.
+//
+// A typical application loads a base configuration file, an environment specific override, a .env file and the
+// environment variables of the process:
+//
+//	cfg, err := config.Load(
+//	    config.File("config/parameters.yml"),
+//	    config.OptionalFile("config/parameters." + env + ".yml"),
+//	    config.OptionalDotEnvFile(".env", "APP_"),
+//	    config.Env("APP_"),
+//	)
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//
+//	container := goldi.NewContainer(registry, cfg.Values)
+//
+// Sources that are given later take precedence over the ones that are given earlier. Nested maps are merged
+// recursively while all other values (including slices) of a later source replace the values of earlier sources.
+// Config.Origin reports from which source each value came from.
+package config
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+)
+
+// Config holds the merged values of all sources and remembers from which source each value came from.
+type Config struct {
+	// Values contains the merged configuration. All nested maps are of type map[string]interface{} so the
+	// values can be referenced with dotted parameter paths like %database.primary.host%.
+	Values map[string]interface{}
+
+	origins map[string]string
+}
+
+// Load loads all sources and deep-merges them into a single configuration.
+// Sources that are given later take precedence over the ones that are given earlier.
+func Load(sources ...Source) (*Config, error) {
+	c := &Config{
+		Values:  map[string]interface{}{},
+		origins: map[string]string{},
+	}
+
+	for _, source := range sources {
+		values, err := source.Load()
+		if err != nil {
+			return nil, fmt.Errorf("config: could not load %s: %w", source.Name(), err)
+		}
+
+		if values != nil {
+			c.merge(c.Values, normalize(values).(map[string]interface{}), "", source.Name())
+		}
+	}
+
+	return c, nil
+}
+
+// Origin returns the name of the source that defined the value with the given dotted key.
+// Only leaf values (everything that is not a map) have an origin.
+func (c *Config) Origin(key string) (string, bool) {
+	origin, exists := c.origins[key]
+	return origin, exists
+}
+
+// Origins returns the dotted keys of all leaf values mapped to the name of the source that defined them.
+func (c *Config) Origins() map[string]string {
+	return maps.Clone(c.origins)
+}
+
+// Keys returns the sorted dotted keys of all leaf values.
+func (c *Config) Keys() []string {
+	return slices.Sorted(maps.Keys(c.origins))
+}
+
+func (c *Config) merge(dst, src map[string]interface{}, prefix, origin string) {
+	for key, value := range src {
+		path := prefix + key
+		srcMap, srcIsMap := value.(map[string]interface{})
+		dstMap, dstIsMap := dst[key].(map[string]interface{})
+
+		switch {
+		case srcIsMap && dstIsMap:
+			c.merge(dstMap, srcMap, path+".", origin)
+		case srcIsMap:
+			c.forget(path)
+			dstMap = map[string]interface{}{}
+			dst[key] = dstMap
+			c.merge(dstMap, srcMap, path+".", origin)
+		default:
+			c.forget(path)
+			dst[key] = value
+			c.origins[path] = origin
+		}
+	}
+}
+
+// forget removes the origins of the value with the given key and all values nested below it.
+func (c *Config) forget(path string) {
+	delete(c.origins, path)
+	for key := range c.origins {
+		if strings.HasPrefix(key, path+".") {
+			delete(c.origins, key)
+		}
+	}
+}
+
+// normalize converts all maps with interface{} keys (as created by some yaml libraries) into maps with string keys.
+func normalize(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		normalized := make(map[string]interface{}, len(v))
+		for key, element := range v {
+			normalized[key] = normalize(element)
+		}
+		return normalized
+	case map[interface{}]interface{}:
+		normalized := make(map[string]interface{}, len(v))
+		for key, element := range v {
+			normalized[fmt.Sprint(key)] = normalize(element)
+		}
+		return normalized
+	case []interface{}:
+		normalized := make([]interface{}, len(v))
+		for i, element := range v {
+			normalized[i] = normalize(element)
+		}
+		return normalized
+	default:
+		return value
+	}
+}
@@ -0,0 +1,176 @@
+package config_test
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/tarokamikaze/goldi"
+	"github.com/tarokamikaze/goldi/config"
+)
+
+func NewAddress(host string, port int) string {
+	return fmt.Sprintf("%s:%d", host, port)
+}
+
+func ExampleLoad() {
+	cfg, err := config.Load(
+		config.Map("defaults", map[string]interface{}{
+			"http": map[string]interface{}{"host": "localhost", "port": 8080},
+		}),
+		config.Map("overrides", map[string]interface{}{
+			"http": map[string]interface{}{"port": 80},
+		}),
+	)
+	if err != nil {
+		panic(err)
+	}
+
+	container := goldi.NewContainer(goldi.NewTypeRegistry(), cfg.Values)
+	container.RegisterType("address", NewAddress, "%http.host%", "%http.port%")
+
+	origin, _ := cfg.Origin("http.port")
+	fmt.Println(container.MustGet("address"), "(port from", origin+")")
+	// Output:
+	// localhost:80 (port from overrides)
+}
+
+var _ = Describe("Load", func() {
+	var dir string
+
+	writeFile := func(name, content string) string {
+		path := filepath.Join(dir, name)
+		Expect(os.WriteFile(path, []byte(content), 0600)).To(Succeed())
+		return path
+	}
+
+	setenv := func(name, value string) {
+		Expect(os.Setenv(name, value)).To(Succeed())
+		DeferCleanup(os.Unsetenv, name)
+	}
+
+	BeforeEach(func() {
+		dir = GinkgoT().TempDir()
+	})
+
+	It("should deep-merge all sources with later sources taking precedence", func() {
+		base := writeFile("parameters.yml", `
+database:
+    host: localhost
+    port: 5432
+    replicas: [replica-1, replica-2]
+log_level: info
+`)
+		prod := writeFile("parameters.prod.json", `{"database": {"host": "db.example.com", "replicas": ["replica-3"]}}`)
+		dotenv := writeFile(".env", "LOG_LEVEL=debug\nDEBUG=true\n")
+		setenv("GOLDI_TEST_DATABASE__PORT", "6432")
+		setenv("GOLDI_TEST_LOG_LEVEL", "warn")
+
+		cfg, err := config.Load(config.File(base), config.File(prod), config.File(dotenv), config.Env("GOLDI_TEST_"))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(cfg.Values).To(Equal(map[string]interface{}{
+			"database": map[string]interface{}{
+				"host":     "db.example.com",
+				"port":     "6432",
+				"replicas": []interface{}{"replica-3"},
+			},
+			"log_level": "warn",
+			"debug":     "true",
+		}))
+
+		Expect(cfg.Origins()).To(Equal(map[string]string{
+			"database.host":     prod,
+			"database.port":     "environment variables GOLDI_TEST_*",
+			"database.replicas": prod,
+			"log_level":         "environment variables GOLDI_TEST_*",
+			"debug":             dotenv,
+		}))
+	})
+
+	It("should layer .env files with environment variables of the same prefix", func() {
+		dotenv := writeFile(".env", "GOLDI_TEST_APP_DATABASE__HOST=localhost\nGOLDI_TEST_APP_DATABASE__PORT=5432\nGOLDI_TEST_APP_LOG_LEVEL=debug\nOTHER=ignored\n")
+		setenv("GOLDI_TEST_APP_DATABASE__HOST", "db.example.com")
+
+		cfg, err := config.Load(config.OptionalDotEnvFile(dotenv, "GOLDI_TEST_APP_"), config.Env("GOLDI_TEST_APP_"))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(cfg.Values).To(Equal(map[string]interface{}{
+			"database":  map[string]interface{}{"host": "db.example.com", "port": "5432"},
+			"log_level": "debug",
+		}))
+
+		Expect(cfg.Origins()).To(Equal(map[string]string{
+			"database.host": "environment variables GOLDI_TEST_APP_*",
+			"database.port": dotenv,
+			"log_level":     dotenv,
+		}))
+	})
+
+	It("should convert maps with interface{} keys into maps with string keys", func() {
+		cfg, err := config.Load(config.File(writeFile("parameters.yaml", "ports:\n    80: http\n    443: https\n")))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(cfg.Values["ports"]).To(Equal(map[string]interface{}{"80": "http", "443": "https"}))
+	})
+
+	It("should replace maps with scalars and forget their origins", func() {
+		cfg, err := config.Load(
+			config.Map("defaults", map[string]interface{}{"cache": map[string]interface{}{"host": "redis"}}),
+			config.Map("overrides", map[string]interface{}{"cache": false}),
+		)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(cfg.Values).To(Equal(map[string]interface{}{"cache": false}))
+		Expect(cfg.Keys()).To(Equal([]string{"cache"}))
+	})
+
+	It("should ignore optional files that do not exist", func() {
+		cfg, err := config.Load(config.OptionalFile(filepath.Join(dir, "parameters.local.yml")))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(cfg.Values).To(BeEmpty())
+	})
+
+	It("should return an error if a file does not exist", func() {
+		path := filepath.Join(dir, "parameters.yml")
+		_, err := config.Load(config.File(path))
+		Expect(err).To(MatchError(ContainSubstring("config: could not load " + path)))
+	})
+
+	It("should return an error if the file format is unknown", func() {
+		_, err := config.Load(config.File(writeFile("parameters.ini", "")))
+		Expect(err).To(MatchError(ContainSubstring("unknown file format")))
+	})
+
+	It("should load files with an explicit format", func() {
+		cfg, err := config.Load(config.FileWithFormat(writeFile("parameters.conf", "port = 80"), config.TOML))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(cfg.Values).To(Equal(map[string]interface{}{"port": 80}))
+	})
+
+	It("should return an error if a file is invalid", func() {
+		_, err := config.Load(config.File(writeFile("parameters.json", "{")))
+		Expect(err).To(HaveOccurred())
+	})
+
+	Describe("Section", func() {
+		It("should only use the values of the given section", func() {
+			types := writeFile("types.yml", `
+parameters:
+    client_base_url: http://example.com
+types:
+    logger:
+        package: github.com/fgrosse/foobar
+        type:    MyType
+`)
+			cfg, err := config.Load(config.Section("parameters", config.File(types)))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(cfg.Values).To(Equal(map[string]interface{}{"client_base_url": "http://example.com"}))
+			origin, _ := cfg.Origin("client_base_url")
+			Expect(origin).To(Equal(types + " (parameters)"))
+		})
+
+		It("should return an error if the section is no map", func() {
+			_, err := config.Load(config.Section("parameters", config.File(writeFile("types.yml", "parameters: 42"))))
+			Expect(err).To(MatchError(ContainSubstring(`"parameters" is no map but int`)))
+		})
+	})
+})
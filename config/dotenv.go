@@ -0,0 +1,84 @@
+package config
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// parseDotEnv parses .env files with one KEY=VALUE pair per line.
+// The keys are converted like the names of environment variables (see Env) so DB_HOST=localhost can be referenced
+// as %db_host% and DATABASE__HOST=localhost as %database.host%.
+//
+// Lines may start with "export" and empty lines or lines starting with # are ignored. Values can be unquoted
+// (surrounding whitespace and trailing comments are removed), single quoted (used literally) or double quoted
+// (escape sequences like \n are supported).
+func parseDotEnv(data []byte) (map[string]interface{}, error) {
+	return parseDotEnvWithPrefix(data, "")
+}
+
+// DotEnvWithPrefix returns a Format that parses .env files like DotEnv but only uses the keys that start with the
+// given prefix and removes the prefix like Env does. Use it with DotEnvFile to layer a .env file that contains the
+// same variables as the environment (e.g. APP_DATABASE__HOST) with Env.
+func DotEnvWithPrefix(prefix string) Format {
+	return func(data []byte) (map[string]interface{}, error) {
+		return parseDotEnvWithPrefix(data, prefix)
+	}
+}
+
+func parseDotEnvWithPrefix(data []byte, prefix string) (map[string]interface{}, error) {
+	values := map[string]interface{}{}
+	for i, line := range strings.Split(string(data), "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || line[0] == '#' {
+			continue
+		}
+
+		line = strings.TrimSpace(strings.TrimPrefix(line, "export "))
+		key, value, hasValue := strings.Cut(line, "=")
+		key = strings.TrimSpace(key)
+		if hasValue == false || key == "" || strings.ContainsAny(key, " \t") {
+			return nil, fmt.Errorf("line %d: expected KEY=VALUE but got %q", i+1, line)
+		}
+
+		value, err := parseDotEnvValue(strings.TrimSpace(value))
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", i+1, err)
+		}
+
+		if keys, hasPrefix := envKeys(key, prefix); hasPrefix {
+			setNested(values, keys, value)
+		}
+	}
+
+	return values, nil
+}
+
+func parseDotEnvValue(value string) (string, error) {
+	if value == "" {
+		return "", nil
+	}
+
+	switch quote := value[0]; quote {
+	case '"', '\'':
+		end := strings.LastIndexByte(value, quote)
+		if end == 0 {
+			return "", fmt.Errorf("unterminated value %s", value)
+		}
+
+		if rest := strings.TrimSpace(value[end+1:]); rest != "" && rest[0] != '#' {
+			return "", fmt.Errorf("unexpected %q after quoted value", rest)
+		}
+
+		if quote == '\'' {
+			return value[1:end], nil
+		}
+
+		return strconv.Unquote(value[:end+1])
+	default:
+		if i := strings.Index(value, " #"); i >= 0 {
+			value = value[:i]
+		}
+		return strings.TrimSpace(value), nil
+	}
+}
@@ -0,0 +1,54 @@
+package config_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/tarokamikaze/goldi/config"
+)
+
+var _ = Describe("DotEnv", func() {
+	It("should parse all key value pairs", func() {
+		values, err := config.DotEnv([]byte(`
+# database settings
+DB_HOST=localhost # the host
+export DB_PORT = 5432
+DB_PASSWORD='p@ss # word'
+GREETING="hello\nworld" # comment
+EMPTY=
+`))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(values).To(Equal(map[string]interface{}{
+			"db_host":     "localhost",
+			"db_port":     "5432",
+			"db_password": "p@ss # word",
+			"greeting":    "hello\nworld",
+			"empty":       "",
+		}))
+	})
+
+	It("should convert the keys like the names of environment variables", func() {
+		values, err := config.DotEnv([]byte("DATABASE__HOST=localhost\nDATABASE__PORT=5432\nLOG_LEVEL=debug\n"))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(values).To(Equal(map[string]interface{}{
+			"database":  map[string]interface{}{"host": "localhost", "port": "5432"},
+			"log_level": "debug",
+		}))
+	})
+
+	It("should only use the keys with the given prefix", func() {
+		values, err := config.DotEnvWithPrefix("APP_")([]byte("APP_DATABASE__HOST=localhost\nAPP_LOG_LEVEL=debug\nOTHER=value\nAPP_=empty\n"))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(values).To(Equal(map[string]interface{}{
+			"database":  map[string]interface{}{"host": "localhost"},
+			"log_level": "debug",
+		}))
+	})
+
+	It("should return an error for invalid lines", func() {
+		_, err := config.DotEnv([]byte("DB_HOST"))
+		Expect(err).To(MatchError(`line 1: expected KEY=VALUE but got "DB_HOST"`))
+
+		_, err = config.DotEnv([]byte(`A="unterminated`))
+		Expect(err).To(MatchError(`line 1: unterminated value "unterminated`))
+	})
+})
@@ -0,0 +1,237 @@
+package config
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v2"
+)
+
+// A Source provides configuration values that can be merged using Load.
+type Source interface {
+	// Name is used in error messages and reported by Config.Origin.
+	Name() string
+	Load() (map[string]interface{}, error)
+}
+
+// A Format parses the content of a configuration file.
+type Format func(data []byte) (map[string]interface{}, error)
+
+// The supported file formats.
+var (
+	YAML   Format = parseYAML
+	JSON   Format = parseJSON
+	TOML   Format = parseTOML
+	DotEnv Format = parseDotEnv
+)
+
+type fileSource struct {
+	path     string
+	format   Format
+	optional bool
+}
+
+// File returns a Source that reads the file with the given path.
+// The format is detected from the file name: .yml and .yaml files are parsed as YAML, .json files as JSON,
+// .toml files as TOML (see TOML for the supported subset) and .env files (also .env.local etc.) as DotEnv.
+// Use FileWithFormat for all other files.
+func File(path string) Source {
+	return &fileSource{path: path, format: detectFormat(path)}
+}
+
+// OptionalFile works like File but does not return an error if the file does not exist.
+// Use this for environment specific overrides or local .env files.
+func OptionalFile(path string) Source {
+	return &fileSource{path: path, format: detectFormat(path), optional: true}
+}
+
+// DotEnvFile returns a Source that reads the .env file with the given path. Only the keys that start with the given
+// prefix are used and their names are converted like the names of environment variables (see Env and
+// DotEnvWithPrefix) so a .env file can provide defaults for the environment variables of the process:
+//
+//	config.Load(config.OptionalDotEnvFile(".env", "APP_"), config.Env("APP_"))
+func DotEnvFile(path, prefix string) Source {
+	return &fileSource{path: path, format: DotEnvWithPrefix(prefix)}
+}
+
+// OptionalDotEnvFile works like DotEnvFile but does not return an error if the file does not exist.
+func OptionalDotEnvFile(path, prefix string) Source {
+	return &fileSource{path: path, format: DotEnvWithPrefix(prefix), optional: true}
+}
+
+// FileWithFormat returns a Source that reads the file with the given path using the given Format.
+func FileWithFormat(path string, format Format) Source {
+	return &fileSource{path: path, format: format}
+}
+
+func (s *fileSource) Name() string {
+	return s.path
+}
+
+func (s *fileSource) Load() (map[string]interface{}, error) {
+	if s.format == nil {
+		return nil, fmt.Errorf("unknown file format (supported are .yml, .yaml, .json, .toml and .env)")
+	}
+
+	data, err := os.ReadFile(s.path)
+	if s.optional && errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return s.format(data)
+}
+
+func detectFormat(path string) Format {
+	name := strings.ToLower(filepath.Base(path))
+	switch {
+	case strings.HasSuffix(name, ".yml"), strings.HasSuffix(name, ".yaml"):
+		return YAML
+	case strings.HasSuffix(name, ".json"):
+		return JSON
+	case strings.HasSuffix(name, ".toml"):
+		return TOML
+	case name == ".env", strings.HasPrefix(name, ".env."), strings.HasSuffix(name, ".env"):
+		return DotEnv
+	default:
+		return nil
+	}
+}
+
+func parseYAML(data []byte) (map[string]interface{}, error) {
+	values := map[string]interface{}{}
+	if err := yaml.Unmarshal(data, &values); err != nil {
+		return nil, err
+	}
+
+	return values, nil
+}
+
+func parseJSON(data []byte) (map[string]interface{}, error) {
+	values := map[string]interface{}{}
+	if len(bytes.TrimSpace(data)) == 0 {
+		return values, nil
+	}
+
+	if err := json.Unmarshal(data, &values); err != nil {
+		return nil, err
+	}
+
+	return values, nil
+}
+
+type envSource struct {
+	prefix string
+}
+
+// Env returns a Source that reads all environment variables of the process that start with the given prefix.
+//
+// The prefix is removed and the remaining name is converted to lower case. Double underscores separate nested keys
+// so APP_DATABASE__HOST becomes database.host (reference it as %database.host%) while APP_LOG_LEVEL becomes log_level.
+// All values are strings which are converted into the argument types when they are injected.
+func Env(prefix string) Source {
+	return &envSource{prefix: prefix}
+}
+
+func (s *envSource) Name() string {
+	return fmt.Sprintf("environment variables %s*", s.prefix)
+}
+
+func (s *envSource) Load() (map[string]interface{}, error) {
+	values := map[string]interface{}{}
+	for _, variable := range os.Environ() {
+		name, value, _ := strings.Cut(variable, "=")
+		if keys, hasPrefix := envKeys(name, s.prefix); hasPrefix {
+			setNested(values, keys, value)
+		}
+	}
+
+	return values, nil
+}
+
+// envKeys removes the prefix from the name of an environment variable and splits the lower case remainder into
+// nested keys at double underscores. It returns false if the name does not start with the prefix.
+func envKeys(name, prefix string) ([]string, bool) {
+	if strings.HasPrefix(name, prefix) == false || len(name) == len(prefix) {
+		return nil, false
+	}
+
+	return strings.Split(strings.ToLower(name[len(prefix):]), "__"), true
+}
+
+// setNested sets the value in the nested map that is identified by the given keys.
+func setNested(values map[string]interface{}, keys []string, value interface{}) {
+	for _, key := range keys[:len(keys)-1] {
+		nested, isMap := values[key].(map[string]interface{})
+		if isMap == false {
+			nested = map[string]interface{}{}
+			values[key] = nested
+		}
+		values = nested
+	}
+
+	values[keys[len(keys)-1]] = value
+}
+
+type mapSource struct {
+	name   string
+	values map[string]interface{}
+}
+
+// Map returns a Source with the given static values (e.g. defaults or values of command line flags).
+func Map(name string, values map[string]interface{}) Source {
+	return &mapSource{name: name, values: values}
+}
+
+func (s *mapSource) Name() string {
+	return s.name
+}
+
+func (s *mapSource) Load() (map[string]interface{}, error) {
+	return s.values, nil
+}
+
+type sectionSource struct {
+	Source
+	key string
+}
+
+// Section returns a Source that only uses the values below the given top level key of another source.
+// This way the parameters section of a goldigen types file can be used as configuration:
+//
+//	config.Load(config.Section("parameters", config.File("config/types.yml")))
+func Section(key string, source Source) Source {
+	return &sectionSource{Source: source, key: key}
+}
+
+func (s *sectionSource) Name() string {
+	return fmt.Sprintf("%s (%s)", s.Source.Name(), s.key)
+}
+
+func (s *sectionSource) Load() (map[string]interface{}, error) {
+	values, err := s.Source.Load()
+	if err != nil || values == nil {
+		return nil, err
+	}
+
+	section, exists := normalize(values).(map[string]interface{})[s.key]
+	if exists == false || section == nil {
+		return nil, nil
+	}
+
+	sectionMap, isMap := section.(map[string]interface{})
+	if isMap == false {
+		return nil, fmt.Errorf("%q is no map but %T", s.key, section)
+	}
+
+	return sectionMap, nil
+}
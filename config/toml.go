@@ -0,0 +1,274 @@
+package config
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// parseTOML parses the subset of TOML that is commonly used for configuration files:
+//   - tables ([database] or [database.primary]) and dotted keys (primary.host = "localhost")
+//   - basic ("...") and literal ('...') strings, integers, floats, booleans and single line arrays of these values
+//   - comments starting with #
+//
+// Multi-line strings, inline tables, arrays of tables and dates are not supported and result in an error.
+func parseTOML(data []byte) (map[string]interface{}, error) {
+	values := map[string]interface{}{}
+	table := values
+
+	for i, line := range strings.Split(string(data), "\n") {
+		p := &tomlParser{input: strings.TrimSpace(line)}
+		if err := p.parseLine(values, &table); err != nil {
+			return nil, fmt.Errorf("line %d: %w", i+1, err)
+		}
+	}
+
+	return values, nil
+}
+
+type tomlParser struct {
+	input string
+	pos   int
+}
+
+func (p *tomlParser) parseLine(root map[string]interface{}, table *map[string]interface{}) error {
+	p.skipSpace()
+	switch {
+	case p.done() || p.peek() == '#':
+		return nil
+	case strings.HasPrefix(p.input[p.pos:], "[["):
+		return fmt.Errorf("arrays of tables are not supported")
+	case p.peek() == '[':
+		p.pos++
+		keys, err := p.parseKey()
+		if err != nil {
+			return err
+		}
+
+		if err := p.expect(']'); err != nil {
+			return err
+		}
+
+		*table, err = tomlTable(root, keys)
+		if err != nil {
+			return err
+		}
+
+		return p.expectEnd()
+	}
+
+	keys, err := p.parseKey()
+	if err != nil {
+		return err
+	}
+
+	if err := p.expect('='); err != nil {
+		return err
+	}
+
+	value, err := p.parseValue()
+	if err != nil {
+		return err
+	}
+
+	parent, err := tomlTable(*table, keys[:len(keys)-1])
+	if err != nil {
+		return err
+	}
+
+	key := keys[len(keys)-1]
+	if _, exists := parent[key]; exists {
+		return fmt.Errorf("key %q is defined twice", strings.Join(keys, "."))
+	}
+
+	parent[key] = value
+	return p.expectEnd()
+}
+
+// tomlTable returns the nested table with the given keys and creates it if it does not exist yet.
+func tomlTable(table map[string]interface{}, keys []string) (map[string]interface{}, error) {
+	for _, key := range keys {
+		switch nested := table[key].(type) {
+		case nil:
+			created := map[string]interface{}{}
+			table[key] = created
+			table = created
+		case map[string]interface{}:
+			table = nested
+		default:
+			return nil, fmt.Errorf("key %q is no table", key)
+		}
+	}
+
+	return table, nil
+}
+
+// parseKey parses a bare, quoted or dotted key.
+func (p *tomlParser) parseKey() ([]string, error) {
+	var keys []string
+	for {
+		p.skipSpace()
+		var key string
+		switch {
+		case p.done():
+			return nil, fmt.Errorf("missing key")
+		case p.peek() == '"' || p.peek() == '\'':
+			value, err := p.parseString()
+			if err != nil {
+				return nil, err
+			}
+			key = value
+		default:
+			start := p.pos
+			for p.done() == false && isBareKeyChar(p.peek()) {
+				p.pos++
+			}
+			key = p.input[start:p.pos]
+			if key == "" {
+				return nil, fmt.Errorf("invalid key at %q", p.input[p.pos:])
+			}
+		}
+
+		keys = append(keys, key)
+		p.skipSpace()
+		if p.done() || p.peek() != '.' {
+			return keys, nil
+		}
+		p.pos++
+	}
+}
+
+func isBareKeyChar(c byte) bool {
+	return c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' || c == '_' || c == '-'
+}
+
+func (p *tomlParser) parseValue() (interface{}, error) {
+	p.skipSpace()
+	if p.done() {
+		return nil, fmt.Errorf("missing value")
+	}
+
+	switch p.peek() {
+	case '"', '\'':
+		if strings.HasPrefix(p.input[p.pos:], `"""`) || strings.HasPrefix(p.input[p.pos:], "'''") {
+			return nil, fmt.Errorf("multi-line strings are not supported")
+		}
+		return p.parseString()
+	case '[':
+		return p.parseArray()
+	case '{':
+		return nil, fmt.Errorf("inline tables are not supported")
+	}
+
+	start := p.pos
+	for p.done() == false && strings.IndexByte(" \t,]#", p.peek()) < 0 {
+		p.pos++
+	}
+
+	literal := p.input[start:p.pos]
+	switch literal {
+	case "true":
+		return true, nil
+	case "false":
+		return false, nil
+	}
+
+	number := strings.ReplaceAll(literal, "_", "")
+	if i, err := strconv.ParseInt(number, 0, 64); err == nil {
+		return int(i), nil
+	}
+
+	if f, err := strconv.ParseFloat(number, 64); err == nil {
+		return f, nil
+	}
+
+	return nil, fmt.Errorf("invalid value %q", literal)
+}
+
+func (p *tomlParser) parseArray() (interface{}, error) {
+	p.pos++ // skip [
+	values := []interface{}{}
+	for {
+		p.skipSpace()
+		if p.done() {
+			return nil, fmt.Errorf("unterminated array (multi-line arrays are not supported)")
+		}
+
+		if p.peek() == ']' {
+			p.pos++
+			return values, nil
+		}
+
+		value, err := p.parseValue()
+		if err != nil {
+			return nil, err
+		}
+		values = append(values, value)
+
+		p.skipSpace()
+		if p.done() == false && p.peek() == ',' {
+			p.pos++
+		} else if p.done() == false && p.peek() != ']' {
+			return nil, fmt.Errorf("expected , or ] but got %q", p.input[p.pos:])
+		}
+	}
+}
+
+func (p *tomlParser) parseString() (string, error) {
+	quote := p.peek()
+	end := p.pos + 1
+	for ; end < len(p.input); end++ {
+		if p.input[end] == '\\' && quote == '"' {
+			end++
+			continue
+		}
+		if p.input[end] == quote {
+			break
+		}
+	}
+
+	if end >= len(p.input) {
+		return "", fmt.Errorf("unterminated string %s", p.input[p.pos:])
+	}
+
+	raw := p.input[p.pos : end+1]
+	p.pos = end + 1
+	if quote == '\'' {
+		return raw[1 : len(raw)-1], nil
+	}
+
+	return strconv.Unquote(raw)
+}
+
+func (p *tomlParser) expect(c byte) error {
+	p.skipSpace()
+	if p.done() || p.peek() != c {
+		return fmt.Errorf("expected %q at %q", c, p.input[p.pos:])
+	}
+
+	p.pos++
+	return nil
+}
+
+func (p *tomlParser) expectEnd() error {
+	p.skipSpace()
+	if p.done() == false && p.peek() != '#' {
+		return fmt.Errorf("unexpected %q", p.input[p.pos:])
+	}
+
+	return nil
+}
+
+func (p *tomlParser) skipSpace() {
+	for p.done() == false && (p.peek() == ' ' || p.peek() == '\t') {
+		p.pos++
+	}
+}
+
+func (p *tomlParser) done() bool {
+	return p.pos >= len(p.input)
+}
+
+func (p *tomlParser) peek() byte {
+	return p.input[p.pos]
+}
@@ -0,0 +1,59 @@
+package config_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/tarokamikaze/goldi/config"
+)
+
+var _ = Describe("TOML", func() {
+	It("should parse tables, dotted keys and values", func() {
+		values, err := config.TOML([]byte(`
+# the application name
+name = "goldi \"app\""   # trailing comment
+debug = true
+
+[database]
+host = 'C:\data'
+port = 5_432
+timeout = 2.5
+replicas = ["replica-1", "replica-2"]
+primary.weight = -1
+
+[database."read only"]
+enabled = false
+`))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(values).To(Equal(map[string]interface{}{
+			"name":  `goldi "app"`,
+			"debug": true,
+			"database": map[string]interface{}{
+				"host":     `C:\data`,
+				"port":     5432,
+				"timeout":  2.5,
+				"replicas": []interface{}{"replica-1", "replica-2"},
+				"primary":  map[string]interface{}{"weight": -1},
+				"read only": map[string]interface{}{
+					"enabled": false,
+				},
+			},
+		}))
+	})
+
+	It("should return an error for unsupported syntax", func() {
+		for input, message := range map[string]string{
+			"[[servers]]":           "arrays of tables are not supported",
+			"point = { x = 1 }":     "inline tables are not supported",
+			`text = """multi"""`:    "multi-line strings are not supported",
+			"ports = [\n80\n]":      "unterminated array",
+			"date = 1979-05-27":     `invalid value "1979-05-27"`,
+			"a = 1\na = 2":          `line 2: key "a" is defined twice`,
+			"a = 1\n[a]":            `line 2: key "a" is no table`,
+			"name = \"unterminated": "unterminated string",
+			"= 1":                   "invalid key",
+		} {
+			_, err := config.TOML([]byte(input))
+			Expect(err).To(MatchError(ContainSubstring(message)), input)
+		}
+	})
+})
@@ -0,0 +1,305 @@
+package goldi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"reflect"
+	"slices"
+	"strings"
+)
+
+// A ConfigChange describes an update of the container configuration via Container.UpdateConfig.
+type ConfigChange struct {
+	OldConfig map[string]interface{}
+	NewConfig map[string]interface{}
+
+	// Parameters contains the sorted dotted names of all parameters that have been added, changed or removed.
+	// Parameters whose configured values refer to changed parameters (e.g. "%db.host%:%db.port%") are included.
+	Parameters []string
+
+	// Types contains the IDs of all cached types that have been rebuilt because they directly or transitively
+	// consume a changed parameter. The types are ordered such that each type comes after all types it depends on.
+	Types []string
+}
+
+type configListener struct {
+	notify func(change ConfigChange)
+}
+
+// UpdateConfig replaces the configuration of the container and rebuilds all cached types that consume a parameter
+// whose value has changed. A type consumes a parameter if one of its arguments contains the parameter (e.g. "%log.level%"
+// or "http://%api.host%/v1") or if it depends on such a type via a type or tagged reference (see Container.Invalidate).
+// Parameters are compared by their dotted paths so a type that uses the whole "%database%" map is rebuilt if any
+// nested value changes. All other cached instances are kept. The new configuration is compared with a deep copy of
+// the previous one that has been taken by NewContainer or the last UpdateConfig, so the previous map may also be
+// changed in place and passed again.
+//
+// The old instances are stopped and closed just like Container.InvalidateAndClose would do before they are replaced
+// by the rebuilt instances. Rebuilt types whose old instances had been started by Container.Start are started
+// again. Afterwards all listeners that have been registered via OnConfigChange are notified.
+// If some types can not be rebuilt, stopped, closed or started the configuration is updated anyway, the listeners
+// are still notified and the errors are returned together. The failed types are generated again on the next request.
+//
+// Environment variable and file parameters are not compared because their values are read each time a type is
+// generated. Scopes that have been created via NewScope before the update keep using the old configuration.
+func (c *Container) UpdateConfig(ctx context.Context, config map[string]interface{}) (ConfigChange, error) {
+	c.configUpdateMu.Lock()
+	defer c.configUpdateMu.Unlock()
+
+	appliedConfig := copyConfig(config)
+
+	c.configMu.Lock()
+	oldConfig := c.appliedConfig
+	if oldConfig == nil {
+		oldConfig = c.Config
+	}
+
+	c.Config = config
+	c.appliedConfig = appliedConfig
+	listeners := slices.Clone(c.configListeners)
+	c.configMu.Unlock()
+
+	change := ConfigChange{
+		OldConfig:  oldConfig,
+		NewConfig:  config,
+		Parameters: changedParameters(oldConfig, appliedConfig),
+	}
+
+	if len(change.Parameters) == 0 {
+		return change, nil
+	}
+
+	evicted := c.evict(c.dependentTypes(c.parameterConsumers(change.Parameters)...))
+	errs := []error{c.release(ctx, evicted)}
+	for _, typeID := range slices.Backward(evicted.typeIDs) {
+		if _, err := c.GetContext(ctx, typeID); err != nil {
+			errs = append(errs, fmt.Errorf("goldi: could not rebuild type %q after the configuration has changed: %w", typeID, err))
+			continue
+		}
+
+		change.Types = append(change.Types, typeID)
+	}
+
+	errs = append(errs, c.restart(ctx, evicted))
+	for _, listener := range listeners {
+		listener.notify(change)
+	}
+
+	return change, errors.Join(errs...)
+}
+
+// OnConfigChange registers a listener that is called after each Container.UpdateConfig that changed at least one
+// parameter. The listeners are called synchronously in the order in which they have been registered.
+// Call the returned function to remove the listener again.
+//
+//	container.OnConfigChange(func(change goldi.ConfigChange) {
+//	    log.Printf("configuration changed: %v (rebuilt %v)", change.Parameters, change.Types)
+//	})
+func (c *Container) OnConfigChange(listener func(change ConfigChange)) (unsubscribe func()) {
+	registered := &configListener{notify: listener}
+
+	c.configMu.Lock()
+	c.configListeners = append(c.configListeners, registered)
+	c.configMu.Unlock()
+
+	return func() {
+		c.configMu.Lock()
+		defer c.configMu.Unlock()
+		c.configListeners = slices.DeleteFunc(c.configListeners, func(l *configListener) bool { return l == registered })
+	}
+}
+
+// config returns the configuration of the container while no UpdateConfig is replacing it.
+func (c *Container) config() map[string]interface{} {
+	c.configMu.RLock()
+	defer c.configMu.RUnlock()
+	return c.Config
+}
+
+// configs returns the configuration of the container together with the copy of it that UpdateConfig compares the
+// next configuration with.
+func (c *Container) configs() (config, appliedConfig map[string]interface{}) {
+	c.configMu.RLock()
+	defer c.configMu.RUnlock()
+	return c.Config, c.appliedConfig
+}
+
+// parameterConsumers returns the IDs of all types that use at least one of the given parameters in their arguments.
+func (c *Container) parameterConsumers(parameters []string) []string {
+	var consumers []string
+	for _, typeID := range c.visibleTypeIDs() {
+		_, generator, _ := c.lookup(typeID)
+		for _, argument := range generator.Arguments() {
+			if s, isString := argument.(string); isString && refersToAny(s, parameters) {
+				consumers = append(consumers, typeID)
+				break
+			}
+		}
+	}
+
+	return consumers
+}
+
+// changedParameters returns the sorted dotted paths of all values that differ between the two configurations and of
+// all string values of the new configuration that refer to such a value.
+func changedParameters(oldConfig, newConfig map[string]interface{}) []string {
+	changed := map[string]bool{}
+	diffConfig("", oldConfig, newConfig, changed)
+	if len(changed) == 0 {
+		return nil
+	}
+
+	references := map[string]string{}
+	collectStrings("", newConfig, references)
+	for found := true; found; {
+		found = false
+		changedPaths := slices.Collect(maps.Keys(changed))
+		for path, s := range references {
+			if changed[path] == false && refersToAny(s, changedPaths) {
+				changed[path] = true
+				found = true
+			}
+		}
+	}
+
+	return slices.Sorted(maps.Keys(changed))
+}
+
+// diffConfig adds the paths of all values that differ between the two (possibly nested) values to changed.
+func diffConfig(path string, oldValue, newValue interface{}, changed map[string]bool) {
+	oldMap, oldIsMap := configMap(oldValue)
+	newMap, newIsMap := configMap(newValue)
+	if oldIsMap == false || newIsMap == false {
+		if reflect.DeepEqual(oldValue, newValue) == false {
+			changed[path] = true
+		}
+		return
+	}
+
+	for key := range oldMap {
+		if _, exists := newMap[key]; exists == false {
+			changed[joinPath(path, key)] = true
+		}
+	}
+
+	for key, value := range newMap {
+		oldValue, exists := oldMap[key]
+		if exists == false {
+			changed[joinPath(path, key)] = true
+			continue
+		}
+
+		diffConfig(joinPath(path, key), oldValue, value, changed)
+	}
+}
+
+// collectStrings adds all string values of the (possibly nested) value to result using their dotted path as key.
+func collectStrings(path string, value interface{}, result map[string]string) {
+	if s, isString := value.(string); isString {
+		result[path] = s
+		return
+	}
+
+	entries, isMap := configMap(value)
+	for key, nested := range entries {
+		collectStrings(joinPath(path, key), nested, result)
+	}
+
+	if isMap == false && value != nil && reflect.TypeOf(value).Kind() == reflect.Slice {
+		slice := reflect.ValueOf(value)
+		for i := 0; i < slice.Len(); i++ {
+			collectStrings(joinPath(path, fmt.Sprint(i)), slice.Index(i).Interface(), result)
+		}
+	}
+}
+
+// copyConfig returns a deep copy of the given configuration so later changes to the (nested) maps and slices of the
+// configuration do not affect the copy.
+func copyConfig(config map[string]interface{}) map[string]interface{} {
+	if config == nil {
+		return nil
+	}
+
+	return copyConfigValue(config).(map[string]interface{})
+}
+
+func copyConfigValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		copied := make(map[string]interface{}, len(v))
+		for key, element := range v {
+			copied[key] = copyConfigValue(element)
+		}
+		return copied
+	case map[interface{}]interface{}:
+		copied := make(map[interface{}]interface{}, len(v))
+		for key, element := range v {
+			copied[key] = copyConfigValue(element)
+		}
+		return copied
+	case []interface{}:
+		if v == nil {
+			return v
+		}
+
+		copied := make([]interface{}, len(v))
+		for i, element := range v {
+			copied[i] = copyConfigValue(element)
+		}
+		return copied
+	}
+
+	if value != nil && reflect.TypeOf(value).Kind() == reflect.Slice && reflect.ValueOf(value).IsNil() == false {
+		slice := reflect.ValueOf(value)
+		copied := reflect.MakeSlice(slice.Type(), slice.Len(), slice.Len())
+		reflect.Copy(copied, slice)
+		return copied.Interface()
+	}
+
+	return value
+}
+
+// configMap returns the entries of the given value if it is a map[string]interface{} or map[interface{}]interface{}.
+func configMap(value interface{}) (map[string]interface{}, bool) {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		return v, true
+	case map[interface{}]interface{}:
+		entries := make(map[string]interface{}, len(v))
+		for key, element := range v {
+			entries[fmt.Sprint(key)] = element
+		}
+		return entries, true
+	default:
+		return nil, false
+	}
+}
+
+func joinPath(path, key string) string {
+	if path == "" {
+		return key
+	}
+
+	return path + "." + key
+}
+
+// refersToAny returns whether the given string contains a parameter that overlaps with any of the given dotted paths.
+// A parameter overlaps with a path if it is the same path or if one of them is nested below the other one.
+func refersToAny(s string, paths []string) bool {
+	for _, name := range ParameterNames(s) {
+		name, _, _ = SplitParameterDefault(name)
+		if IsProcessedParameter(name) {
+			continue
+		}
+
+		for _, path := range paths {
+			if name == path || strings.HasPrefix(name, path+".") || strings.HasPrefix(path, name+".") {
+				return true
+			}
+		}
+	}
+
+	return false
+}
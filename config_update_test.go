@@ -0,0 +1,291 @@
+package goldi_test
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/tarokamikaze/goldi"
+)
+
+func ExampleContainer_UpdateConfig() {
+	registry := goldi.NewTypeRegistry()
+	registry.RegisterType("logger", NewLevelLogger, "%log.level%")
+	registry.RegisterType("mock", NewMockType)
+
+	container := goldi.NewContainer(registry, map[string]interface{}{
+		"log": map[string]interface{}{"level": "info"},
+	})
+
+	container.OnConfigChange(func(change goldi.ConfigChange) {
+		fmt.Printf("changed %v and rebuilt %v\n", change.Parameters, change.Types)
+	})
+
+	fmt.Println(container.MustGet("logger").(*LevelLogger).Level)
+	container.MustGet("mock")
+
+	container.UpdateConfig(context.Background(), map[string]interface{}{
+		"log": map[string]interface{}{"level": "debug"},
+	})
+
+	fmt.Println(container.MustGet("logger").(*LevelLogger).Level)
+	// Output:
+	// info
+	// changed [log.level] and rebuilt [logger]
+	// debug
+}
+
+// LevelLogger is a test type that consumes a parameter
+type LevelLogger struct {
+	Level string
+}
+
+func NewLevelLogger(level string) *LevelLogger {
+	return &LevelLogger{Level: level}
+}
+
+var _ = Describe("Container configuration updates", func() {
+	var (
+		registry  goldi.TypeRegistry
+		container *goldi.Container
+	)
+
+	BeforeEach(func() {
+		registry = goldi.NewTypeRegistry()
+		registry.RegisterType("logger", NewLevelLogger, "%log.level%")
+		registry.RegisterType("handler", NewTypeForServiceInjectionWithArgs, "@mock", "%handler.name%", "http://%api.host%:%api.port%", false)
+		registry.RegisterType("mock", NewMockTypeWithArgs, "%mock.name%", false)
+		registry.RegisterType("client", NewTypeForServiceInjection, "@mock")
+		registry.RegisterType("unrelated", NewFoo)
+
+		container = goldi.NewContainer(registry, map[string]interface{}{
+			"log":          map[string]interface{}{"level": "info"},
+			"api":          map[string]interface{}{"host": "localhost", "port": 8080},
+			"handler.name": "handler",
+			"mock.name":    "mock",
+		})
+	})
+
+	It("should stop and close the old instances and start the new instances", func() {
+		log := new(ShutdownLog)
+		container.InjectInstance("log", log)
+		container.Register("server", goldi.NewEagerType(goldi.NewStructType(&Server{}, "server:%api.port%", "@log")))
+		Expect(container.Start(context.Background())).To(Succeed())
+
+		change, err := container.UpdateConfig(context.Background(), map[string]interface{}{
+			"log": map[string]interface{}{"level": "info"},
+			"api": map[string]interface{}{"host": "localhost", "port": 8081},
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(change.Types).To(Equal([]string{"server"}))
+
+		Expect(container.Stop(context.Background())).To(Succeed())
+		Expect(container.Close(context.Background())).To(Succeed())
+		Expect(log.Events).To(Equal([]string{
+			"start server:8080",
+			"stop server:8080", "close server:8080", "start server:8081",
+			"stop server:8081", "close server:8081",
+		}))
+	})
+
+	It("should rebuild the types that are autowired with a rebuilt type", func() {
+		container.InjectInstance("log", new(ShutdownLog))
+		container.Register("db", goldi.NewStructType(&Server{}, "db:%api.port%", "@log"))
+		container.Register("users", goldi.NewAutowiredType(NewUsers))
+		users := container.MustGet("users").(*Users)
+
+		change, err := container.UpdateConfig(context.Background(), map[string]interface{}{
+			"log": map[string]interface{}{"level": "info"},
+			"api": map[string]interface{}{"host": "localhost", "port": 8081},
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(change.Types).To(Equal([]string{"db", "users"}))
+
+		rebuilt := container.MustGet("users").(*Users)
+		Expect(rebuilt).NotTo(BeIdenticalTo(users))
+		Expect(rebuilt.DB.Name).To(Equal("db:8081"))
+	})
+
+	It("should use the new configuration", func() {
+		container.MustGet("logger")
+
+		_, err := container.UpdateConfig(context.Background(), map[string]interface{}{"log": map[string]interface{}{"level": "debug"}})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(container.Config).To(HaveKey("log"))
+		Expect(container.MustGet("logger").(*LevelLogger).Level).To(Equal("debug"))
+	})
+
+	It("should rebuild the types if the configuration has been changed in place", func() {
+		config := map[string]interface{}{"log": map[string]interface{}{"level": "info"}}
+		container = goldi.NewContainer(registry, config)
+		container.MustGet("logger")
+
+		config["log"].(map[string]interface{})["level"] = "debug"
+		change, err := container.UpdateConfig(context.Background(), config)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(change.Parameters).To(Equal([]string{"log.level"}))
+		Expect(change.Types).To(Equal([]string{"logger"}))
+		Expect(change.OldConfig).To(Equal(map[string]interface{}{"log": map[string]interface{}{"level": "info"}}))
+		Expect(container.MustGet("logger").(*LevelLogger).Level).To(Equal("debug"))
+
+		config["level"] = "warn"
+		config["log"] = map[string]interface{}{"level": "%level%"}
+		change, err = container.UpdateConfig(context.Background(), config)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(change.Parameters).To(Equal([]string{"level", "log.level"}))
+		Expect(container.MustGet("logger").(*LevelLogger).Level).To(Equal("warn"))
+	})
+
+	It("should only rebuild the types that consume a changed parameter", func() {
+		logger := container.MustGet("logger")
+		handler := container.MustGet("handler")
+		unrelated := container.MustGet("unrelated")
+
+		change, err := container.UpdateConfig(context.Background(), map[string]interface{}{
+			"log":          map[string]interface{}{"level": "info"},
+			"api":          map[string]interface{}{"host": "example.com", "port": 8080},
+			"handler.name": "handler",
+			"mock.name":    "mock",
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(change.Parameters).To(Equal([]string{"api.host"}))
+		Expect(change.Types).To(Equal([]string{"handler"}))
+
+		Expect(container.MustGet("logger")).To(BeIdenticalTo(logger))
+		Expect(container.MustGet("unrelated")).To(BeIdenticalTo(unrelated))
+		Expect(container.MustGet("handler")).NotTo(BeIdenticalTo(handler))
+		Expect(container.MustGet("handler").(*TypeForServiceInjection).InjectedType.StringParameter).To(Equal("mock"))
+	})
+
+	It("should rebuild the types that transitively depend on a changed parameter", func() {
+		container.MustGet("client")
+		container.MustGet("handler")
+		container.MustGet("logger")
+
+		change, err := container.UpdateConfig(context.Background(), map[string]interface{}{
+			"log":          map[string]interface{}{"level": "info"},
+			"api":          map[string]interface{}{"host": "localhost", "port": 8080},
+			"handler.name": "handler",
+			"mock.name":    "new mock",
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(change.Types).To(ConsistOf("mock", "client", "handler"))
+		Expect(change.Types[0]).To(Equal("mock"))
+		Expect(container.MustGet("client").(*TypeForServiceInjection).InjectedType.StringParameter).To(Equal("new mock"))
+	})
+
+	It("should rebuild types that use a parent of a changed parameter", func() {
+		registry.RegisterType("api_config", NewAPIConfig, "%api%")
+		container.MustGet("api_config")
+
+		change, err := container.UpdateConfig(context.Background(), map[string]interface{}{
+			"api": map[string]interface{}{"host": "localhost", "port": 9090},
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(change.Parameters).To(Equal([]string{"api.port", "handler.name", "log", "mock.name"}))
+		Expect(change.Types).To(Equal([]string{"api_config"}))
+	})
+
+	It("should rebuild types that use a parameter which refers to a changed parameter", func() {
+		config := map[string]interface{}{"level": "info", "log": map[string]interface{}{"level": "%level%"}}
+		container = goldi.NewContainer(registry, config)
+		container.MustGet("logger")
+
+		change, err := container.UpdateConfig(context.Background(), map[string]interface{}{"level": "warn", "log": map[string]interface{}{"level": "%level%"}})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(change.Parameters).To(Equal([]string{"level", "log.level"}))
+		Expect(change.Types).To(Equal([]string{"logger"}))
+		Expect(container.MustGet("logger").(*LevelLogger).Level).To(Equal("warn"))
+	})
+
+	It("should not rebuild anything if nothing has changed", func() {
+		logger := container.MustGet("logger")
+
+		change, err := container.UpdateConfig(context.Background(), map[string]interface{}{
+			"log":          map[interface{}]interface{}{"level": "info"},
+			"api":          map[string]interface{}{"host": "localhost", "port": 8080},
+			"handler.name": "handler",
+			"mock.name":    "mock",
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(change.Parameters).To(BeEmpty())
+		Expect(change.Types).To(BeEmpty())
+		Expect(container.MustGet("logger")).To(BeIdenticalTo(logger))
+	})
+
+	It("should return an error if a type can not be rebuilt", func() {
+		registry.RegisterType("api_config", NewAPIConfig, "%api%")
+		container.MustGet("api_config")
+
+		change, err := container.UpdateConfig(context.Background(), map[string]interface{}{"api": "invalid"})
+		Expect(err).To(MatchError(ContainSubstring(`goldi: could not rebuild type "api_config" after the configuration has changed`)))
+		Expect(change.Types).To(BeEmpty())
+	})
+
+	It("should be safe to update the configuration while other goroutines retrieve types", func() {
+		var wg sync.WaitGroup
+		for i := 0; i < 10; i++ {
+			wg.Add(2)
+			go func() {
+				defer wg.Done()
+				defer GinkgoRecover()
+				Expect(container.MustGet("logger")).NotTo(BeNil())
+			}()
+			go func() {
+				defer wg.Done()
+				defer GinkgoRecover()
+				container.UpdateConfig(context.Background(), map[string]interface{}{"log": map[string]interface{}{"level": fmt.Sprint(i)}})
+			}()
+		}
+		wg.Wait()
+	})
+
+	Describe("OnConfigChange", func() {
+		It("should notify all listeners in the order in which they have been registered", func() {
+			var notifications []string
+			container.OnConfigChange(func(change goldi.ConfigChange) {
+				notifications = append(notifications, fmt.Sprintf("first %v", change.Parameters))
+			})
+			container.OnConfigChange(func(change goldi.ConfigChange) {
+				Expect(change.OldConfig).To(HaveKeyWithValue("mock.name", "mock"))
+				Expect(change.NewConfig).To(HaveKeyWithValue("mock.name", "changed"))
+				notifications = append(notifications, fmt.Sprintf("second %v", change.Parameters))
+			})
+
+			container.UpdateConfig(context.Background(), map[string]interface{}{"mock.name": "changed"})
+			Expect(notifications).To(Equal([]string{
+				"first [api handler.name log mock.name]",
+				"second [api handler.name log mock.name]",
+			}))
+		})
+
+		It("should not notify listeners if nothing has changed", func() {
+			notified := false
+			container.OnConfigChange(func(goldi.ConfigChange) { notified = true })
+
+			container.UpdateConfig(context.Background(), container.Config)
+			Expect(notified).To(BeFalse())
+		})
+
+		It("should not notify listeners that have unsubscribed", func() {
+			notified := false
+			unsubscribe := container.OnConfigChange(func(goldi.ConfigChange) { notified = true })
+			unsubscribe()
+
+			container.UpdateConfig(context.Background(), map[string]interface{}{})
+			Expect(notified).To(BeFalse())
+		})
+	})
+})
+
+// APIConfig is a test type that consumes a whole configuration map
+type APIConfig struct {
+	Host string
+	Port int
+}
+
+func NewAPIConfig(config APIConfig) *APIConfig {
+	return &config
+}
@@ -51,14 +51,18 @@ func (t *configuredType) Arguments() []interface{} {
 	return append(t.embeddedType.Arguments(), "@"+t.ConfiguratorTypeID)
 }
 
+func (t *configuredType) unwrap() TypeFactory {
+	return t.embeddedType
+}
+
 func (t *configuredType) Generate(parameterResolver *ParameterResolver) (interface{}, error) {
 	embedded, err := t.embeddedType.Generate(parameterResolver)
 	if err != nil {
-		return nil, fmt.Errorf("can not generate configured type: %s", err)
+		return nil, fmt.Errorf("can not generate configured type: %w", err)
 	}
 
-	if err = t.Configure(embedded, parameterResolver.Container); err != nil {
-		return nil, fmt.Errorf("can not configure type: %s", err)
+	if err = t.configure(embedded, parameterResolver); err != nil {
+		return nil, fmt.Errorf("can not configure type: %w", err)
 	}
 
 	return embedded, nil
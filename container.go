@@ -1,16 +1,20 @@
 package goldi
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"iter"
 	"slices"
 	"sync"
+	"sync/atomic"
 )
 
 // Container is the dependency injection container that can be used by your application to define and get types.
 //
 // Basically this is just a TypeRegistry with access to the application configuration and the knowledge
 // of how to build individual services. Additionally this implements the laziness of the DI using a simple in memory type cache
+// All types are cached as singletons unless they have been registered with a different Scope (see NewScopedType).
 //
 // You must use goldi.NewContainer to get a initialized instance of a Container!
 type Container struct {
@@ -18,8 +22,27 @@ type Container struct {
 	Config   map[string]interface{}
 	Resolver *ParameterResolver
 
+	// StrictParameters makes Get return an error if a type uses a parameter that has not been defined and has no
+	// inline default. Otherwise the parameter is injected as is (e.g. the string "%undefined%").
+	StrictParameters bool
+
 	typeCache       sync.Map         // thread-safe cache for generated instances
 	reflectionCache *ReflectionCache // cache for reflection operations
+	parent          *Container       // the parent container if this container has been created via NewScope
+	flights         *flightGroup     // makes sure each cached type is generated only once
+
+	registryMu sync.RWMutex                   // synchronizes the registration methods of the Container with all reads
+	frozen     atomic.Pointer[TypeRegistry]   // the immutable snapshot of the types once the container has been frozen
+	decorators atomic.Pointer[decoratorIndex] // the decorators of the registry by decorated type ID (see decorate)
+
+	configMu        sync.RWMutex           // synchronizes UpdateConfig with all parameter lookups
+	configUpdateMu  sync.Mutex             // makes sure that only one UpdateConfig runs at a time
+	configListeners []*configListener      // all listeners that have been registered via OnConfigChange
+	appliedConfig   map[string]interface{} // a deep copy of Config which UpdateConfig compares the new configuration with
+
+	mu            sync.Mutex
+	creationOrder []string           // the IDs of all cached types in the order in which they have been generated
+	started       []*startedInstance // all instances that have been started by Container.Start (in that order)
 }
 
 // NewContainer creates a new container instance using the provided arguments
@@ -28,6 +51,8 @@ func NewContainer(registry TypeRegistry, config map[string]interface{}) *Contain
 		TypeRegistry:    registry,
 		Config:          config,
 		reflectionCache: NewReflectionCache(),
+		flights:         newFlightGroup(),
+		appliedConfig:   copyConfig(config),
 	}
 
 	c.Resolver = NewParameterResolver(c)
@@ -57,9 +82,22 @@ func (c *Container) MustGet(typeID string) interface{} {
 // implementations. Also make sure your application is properly tested and defers some panic handling in case you
 // forgot to define a service.
 //
-// See also Container.MustGet
+// Get is safe for concurrent use. If multiple goroutines request a type that has not been generated yet,
+// the type is generated only once and all goroutines receive the same instance.
+//
+// See also Container.MustGet and Container.GetContext
 func (c *Container) Get(typeID string) (interface{}, error) {
-	instance, isDefined, err := c.get(typeID)
+	return c.GetContext(context.Background(), typeID)
+}
+
+// GetContext behaves exactly like Get but passes the given context to all type factories that are used to generate
+// the requested type and its dependencies. Factory functions which accept a context.Context as first argument
+// receive this context automatically (see NewType).
+//
+// If the context is canceled or its deadline is exceeded no further types are generated and the context error is
+// returned. Use this to abort slow constructors (e.g. of network clients) on shutdown or when a startup deadline is hit.
+func (c *Container) GetContext(ctx context.Context, typeID string) (interface{}, error) {
+	instance, isDefined, err := c.get(typeID, newResolution(ctx))
 	if err != nil {
 		return nil, err
 	}
@@ -90,6 +128,21 @@ func Get[T any](c *Container, typeID string) (T, error) {
 	return zero, fmt.Errorf("goldi: type %q cannot be asserted to %T", typeID, zero)
 }
 
+// GetContext retrieves a type just like Container.GetContext but with the type safety of Get.
+func GetContext[T any](ctx context.Context, c *Container, typeID string) (T, error) {
+	var zero T
+	instance, err := c.GetContext(ctx, typeID)
+	if err != nil {
+		return zero, err
+	}
+
+	if typed, ok := instance.(T); ok {
+		return typed, nil
+	}
+
+	return zero, fmt.Errorf("goldi: type %q cannot be asserted to %T", typeID, zero)
+}
+
 // MustGet with improved type inference - panics on error but provides type safety
 //
 //go:inline
@@ -101,27 +154,118 @@ func MustGet[T any](c *Container, typeID string) T {
 	return result
 }
 
-func (c *Container) get(typeID string) (interface{}, bool, error) {
+func (c *Container) get(typeID string, res *resolution) (interface{}, bool, error) {
 	// Check cache first (thread-safe read)
 	if cached, ok := c.typeCache.Load(typeID); ok {
 		return cached, true, nil
 	}
 
-	generator, isDefined := c.TypeRegistry[typeID]
+	owner, generator, isDefined := c.lookup(typeID)
 	if isDefined == false {
 		return nil, false, nil
 	}
 
-	instance, err := generator.Generate(c.Resolver)
-	if err != nil {
-		return nil, false, fmt.Errorf("goldi: error while generating type %q: %s", typeID, err)
+	if decorator, isDecorator := findEmbedded[*decoratorType](generator); isDecorator {
+		// decorators are never used on their own but stand for the type they decorate
+		return c.get(decorator.decoratedTypeID, res)
+	}
+
+	switch ScopeOf(generator) {
+	case Prototype:
+		// prototypes are generated on each request and must never be cached
+		return c.generate(typeID, generator, res)
+	case Scoped:
+		// scoped types live in the cache of the container (scope) they have been requested from
+		return c.generateCached(typeID, generator, res)
+	default:
+		// singletons live in the cache of the container they have been registered at
+		return owner.generateCached(typeID, generator, res)
+	}
+}
+
+// lookup searches the type factory of the given type ID in this container and all its parents.
+// It returns the container at which the type has been registered.
+// If decorators for the type have been registered at the same container they are applied to the returned factory.
+func (c *Container) lookup(typeID string) (*Container, TypeFactory, bool) {
+	for container := c; container != nil; container = container.parent {
+		var generator TypeFactory
+		container.readTypes(func(types TypeRegistry) {
+			if factory, isDefined := types[typeID]; isDefined {
+				generator = container.decorate(types, typeID, factory)
+			}
+		})
+
+		if generator != nil {
+			return container, generator, true
+		}
+	}
+
+	return nil, nil, false
+}
+
+func (c *Container) generate(typeID string, generator TypeFactory, res *resolution) (interface{}, bool, error) {
+	if err := res.ctx.Err(); err != nil {
+		return nil, false, fmt.Errorf("goldi: aborted generating type %q: %w", typeID, err)
+	}
+
+	key := flightKey{c, typeID}
+	if i := slices.Index(res.path, key); i >= 0 {
+		return nil, false, newCircularDependencyError(res.path[i:], key)
+	}
+
+	res.path = append(res.path, key)
+	defer func() { res.path = res.path[:len(res.path)-1] }()
+
+	resolver := &ParameterResolver{Container: c, resolution: res}
+	instance, err := generator.Generate(resolver)
+
+	var circularDependency CircularDependencyError
+	var parameterErr *ParameterError
+	switch {
+	case errors.As(err, &circularDependency):
+		// the path of the error already contains all types that were involved
+		return nil, false, circularDependency
+	case errors.As(err, &parameterErr) && parameterErr.TypeID == "":
+		// the error already names the parameter and the argument so it only lacks the type ID
+		parameterErr.TypeID = typeID
+		return nil, false, err
+	case err != nil:
+		return nil, false, fmt.Errorf("goldi: error while generating type %q: %w", typeID, err)
 	}
 
-	// Store in cache (thread-safe write)
-	c.typeCache.Store(typeID, instance)
 	return instance, true, nil
 }
 
+// NewScope creates a child container which can be used for request or job level lifetimes.
+//
+// The child has its own (initially empty) TypeRegistry which falls back to the types of its parent.
+// This way values like the current request or a tenant ID can be injected into the scope using InjectInstance
+// without affecting the parent. Singleton types are still generated and cached by the container they have been
+// registered at, while types with the Scoped scope are cached by the scope they have been requested from.
+// The configuration is shared with the parent.
+//
+// Call Close on the scope when you are done with it to release all instances that have been cached by the scope.
+func (c *Container) NewScope() *Container {
+	config, appliedConfig := c.configs()
+	scope := &Container{
+		TypeRegistry:     NewTypeRegistry(),
+		Config:           config,
+		StrictParameters: c.StrictParameters,
+		reflectionCache:  c.reflectionCache,
+		parent:           c,
+		flights:          c.flights,
+		appliedConfig:    appliedConfig,
+	}
+
+	scope.Resolver = NewParameterResolver(scope)
+	return scope
+}
+
+// Parent returns the container this scope has been created from or nil if c has not been created via NewScope.
+func (c *Container) Parent() *Container {
+	return c.parent
+}
+
 // AllInstances returns an iterator over all cached instances
 // This uses Go 1.24's range over func feature for memory-efficient iteration
 func (c *Container) AllInstances() iter.Seq2[string, interface{}] {
@@ -154,9 +298,12 @@ func (c *Container) GetMultiple(typeIDs iter.Seq[string]) iter.Seq2[string, inte
 	}
 }
 
-// WarmupCache pre-generates instances for all registered types
+// WarmupCache pre-generates instances for all registered types in alphabetical order of their type IDs.
+// See Container.Start if you want to generate only the eager types and start them afterwards.
 func (c *Container) WarmupCache() error {
-	for typeID := range c.TypeRegistry.TypeIDs() {
+	typeIDs := c.typeIDs()
+	slices.Sort(typeIDs)
+	for _, typeID := range typeIDs {
 		if _, err := c.Get(typeID); err != nil {
 			return fmt.Errorf("failed to warmup type %q: %w", typeID, err)
 		}
@@ -182,7 +329,7 @@ func (c *Container) CollectCachedTypeIDs() []string {
 // GetAllInstances retrieves all registered types and returns them as a map
 func (c *Container) GetAllInstances() (map[string]interface{}, error) {
 	result := make(map[string]interface{})
-	for typeID := range c.TypeRegistry.TypeIDs() {
+	for _, typeID := range c.typeIDs() {
 		instance, err := c.Get(typeID)
 		if err != nil {
 			return nil, fmt.Errorf("failed to get instance for type %q: %w", typeID, err)
@@ -0,0 +1,245 @@
+package goldi
+
+import (
+	"errors"
+	"fmt"
+	"iter"
+	"maps"
+	"reflect"
+	"slices"
+)
+
+// ErrFrozen is the error with which all registration methods of a frozen Container panic (see Container.Freeze).
+var ErrFrozen = errors.New("goldi: the container has been frozen")
+
+// A TypeRegistrar is something types can be registered at. Both the TypeRegistry and the Container implement it.
+type TypeRegistrar interface {
+	Register(typeID string, typeDef TypeFactory)
+}
+
+// Register saves a type under the given symbolic typeID just like TypeRegistry.Register.
+//
+// All registration methods of the Container are safe for concurrent use with each other and with Get so late
+// plugins can register their types while other goroutines retrieve types.
+//
+// Writing directly into the underlying TypeRegistry (e.g. via container.TypeRegistry.Register or the registry that
+// has been passed to NewContainer) is NOT synchronized and races with Get. Only do this before the container is
+// used by multiple goroutines and use the registration methods of the Container afterwards.
+//
+// Register panics with ErrFrozen if the container has been frozen.
+func (c *Container) Register(typeID string, typeDef TypeFactory) {
+	c.updateTypes(fmt.Sprintf("type %q", typeID), func(types TypeRegistry) {
+		types.Register(typeID, typeDef)
+	})
+}
+
+// RegisterAll registers all given type factories just like TypeRegistry.RegisterAll.
+// RegisterAll panics with ErrFrozen if the container has been frozen.
+func (c *Container) RegisterAll(factories map[string]TypeFactory) {
+	c.updateTypes("types", func(types TypeRegistry) {
+		types.RegisterAll(factories)
+	})
+}
+
+// RegisterType registers a type just like TypeRegistry.RegisterType.
+// RegisterType panics with ErrFrozen if the container has been frozen.
+func (c *Container) RegisterType(typeID string, factory interface{}, arguments ...interface{}) {
+	c.updateTypes(fmt.Sprintf("type %q", typeID), func(types TypeRegistry) {
+		types.RegisterType(typeID, factory, arguments...)
+	})
+}
+
+// InjectInstance injects a type instance just like TypeRegistry.InjectInstance.
+// InjectInstance panics with ErrFrozen if the container has been frozen.
+func (c *Container) InjectInstance(typeID string, instance interface{}) {
+	c.updateTypes(fmt.Sprintf("type %q", typeID), func(types TypeRegistry) {
+		types.InjectInstance(typeID, instance)
+	})
+}
+
+// InjectOwnedInstance injects a type instance just like TypeRegistry.InjectOwnedInstance.
+// InjectOwnedInstance panics with ErrFrozen if the container has been frozen.
+func (c *Container) InjectOwnedInstance(typeID string, instance interface{}) {
+	c.updateTypes(fmt.Sprintf("type %q", typeID), func(types TypeRegistry) {
+		types.InjectOwnedInstance(typeID, instance)
+	})
+}
+
+// Freeze seals the types of the container. Afterwards all registration methods panic with ErrFrozen and types are
+// looked up in an immutable snapshot of the registry without any locking. Freeze the container once all types have
+// been registered (e.g. after the bootstrapping of your application) to get the fastest read path.
+//
+// Scopes that are created via NewScope are not frozen so request specific values can still be injected into them.
+// Use Clone to get a mutable copy of a frozen container.
+//
+// Freeze replaces the TypeRegistry of the container with the snapshot. Later writes into the registry that has been
+// passed to NewContainer are therefore ignored while writes into container.TypeRegistry are not synchronized and
+// break the immutability of the snapshot, so never modify the registry of a frozen container directly.
+func (c *Container) Freeze() {
+	c.registryMu.Lock()
+	defer c.registryMu.Unlock()
+
+	if c.frozen.Load() != nil {
+		return
+	}
+
+	snapshot := maps.Clone(c.TypeRegistry)
+	if snapshot == nil {
+		snapshot = NewTypeRegistry()
+	}
+
+	c.TypeRegistry = snapshot
+	c.frozen.Store(&snapshot)
+}
+
+// IsFrozen returns whether Freeze has been called on this container.
+func (c *Container) IsFrozen() bool {
+	return c.frozen.Load() != nil
+}
+
+// Clone creates a new mutable container with a copy of the types and the same configuration and parent.
+// The clone does not share any instances with c so all types are generated again when they are requested.
+func (c *Container) Clone() *Container {
+	var registry TypeRegistry
+	c.readTypes(func(types TypeRegistry) {
+		registry = types.Clone()
+	})
+
+	config, appliedConfig := c.configs()
+	clone := &Container{
+		TypeRegistry:     registry,
+		Config:           config,
+		StrictParameters: c.StrictParameters,
+		reflectionCache:  c.reflectionCache,
+		parent:           c.parent,
+		flights:          newFlightGroup(),
+		appliedConfig:    appliedConfig,
+	}
+
+	clone.Resolver = NewParameterResolver(clone)
+	return clone
+}
+
+// updateTypes calls update with the registry of the container while no other goroutine reads or writes it.
+func (c *Container) updateTypes(description string, update func(types TypeRegistry)) {
+	c.registryMu.Lock()
+	defer c.registryMu.Unlock()
+
+	if c.frozen.Load() != nil {
+		panic(fmt.Errorf("goldi: could not register %s: %w", description, ErrFrozen))
+	}
+
+	if c.TypeRegistry == nil {
+		c.TypeRegistry = NewTypeRegistry()
+	}
+
+	update(c.TypeRegistry)
+	c.decorators.Store(nil)
+}
+
+// readTypes calls read with the registry of the container while no other goroutine writes it.
+// The registry must neither be modified nor retained by read.
+func (c *Container) readTypes(read func(types TypeRegistry)) {
+	if frozen := c.frozen.Load(); frozen != nil {
+		read(*frozen)
+		return
+	}
+
+	c.registryMu.RLock()
+	defer c.registryMu.RUnlock()
+	read(c.TypeRegistry)
+}
+
+// typeIDs returns the IDs of all types of the container. Use it instead of readTypes if types are retrieved for
+// the returned IDs because Get must not be called while the registry is read.
+func (c *Container) typeIDs() []string {
+	var typeIDs []string
+	c.readTypes(func(types TypeRegistry) {
+		typeIDs = slices.Collect(maps.Keys(types))
+	})
+
+	return typeIDs
+}
+
+// types returns the registry of the container if it has been frozen and a copy of it otherwise.
+// In contrast to readTypes the returned registry may be retained and ranged over while types are registered.
+func (c *Container) types() TypeRegistry {
+	if frozen := c.frozen.Load(); frozen != nil {
+		return *frozen
+	}
+
+	var types TypeRegistry
+	c.readTypes(func(registry TypeRegistry) {
+		types = registry.Clone()
+	})
+
+	return types
+}
+
+// All returns an iterator over all registered type IDs and their factories just like TypeRegistry.All.
+// The iterator ranges over a copy of the types so it does not see types which are registered in the meantime.
+func (c *Container) All() iter.Seq2[string, TypeFactory] {
+	return c.types().All()
+}
+
+// TypeIDs returns an iterator over all registered type IDs just like TypeRegistry.TypeIDs.
+func (c *Container) TypeIDs() iter.Seq[string] {
+	return c.types().TypeIDs()
+}
+
+// Factories returns an iterator over all registered factories just like TypeRegistry.Factories.
+func (c *Container) Factories() iter.Seq[TypeFactory] {
+	return c.types().Factories()
+}
+
+// FilterByType returns an iterator over the type IDs that match the given type just like TypeRegistry.FilterByType.
+func (c *Container) FilterByType(targetType reflect.Type) iter.Seq[string] {
+	return c.types().FilterByType(targetType)
+}
+
+// CollectTypeIDs collects all registered type IDs into a slice just like TypeRegistry.CollectTypeIDs.
+func (c *Container) CollectTypeIDs() []string {
+	return c.typeIDs()
+}
+
+// CollectFactories collects all registered factories into a slice just like TypeRegistry.CollectFactories.
+func (c *Container) CollectFactories() []TypeFactory {
+	var factories []TypeFactory
+	c.readTypes(func(types TypeRegistry) {
+		factories = types.CollectFactories()
+	})
+
+	return factories
+}
+
+// CollectAll collects all type registrations into a map just like TypeRegistry.CollectAll.
+// The returned map is never the registry of the container itself.
+func (c *Container) CollectAll() map[string]TypeFactory {
+	var all map[string]TypeFactory
+	c.readTypes(func(types TypeRegistry) {
+		all = types.CollectAll()
+	})
+
+	return all
+}
+
+// CollectByType collects the type IDs that match the given type just like TypeRegistry.CollectByType.
+func (c *Container) CollectByType(targetType reflect.Type) []string {
+	var typeIDs []string
+	c.readTypes(func(types TypeRegistry) {
+		typeIDs = types.CollectByType(targetType)
+	})
+
+	return typeIDs
+}
+
+// AutowireCandidates returns the sorted IDs of all types of the container that can be injected into an autowired
+// argument of the given type just like TypeRegistry.AutowireCandidates. Types of parent containers are not included.
+func (c *Container) AutowireCandidates(expectedType reflect.Type) []string {
+	var candidates []string
+	c.readTypes(func(types TypeRegistry) {
+		candidates = types.AutowireCandidates(expectedType)
+	})
+
+	return candidates
+}
@@ -0,0 +1,172 @@
+package goldi_test
+
+import (
+	"fmt"
+	"reflect"
+	"sync"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/tarokamikaze/goldi"
+)
+
+func ExampleContainer_Freeze() {
+	container := goldi.NewContainer(goldi.NewTypeRegistry(), map[string]interface{}{})
+	container.Register("logger", goldi.NewStructType(SimpleLogger{}))
+	container.Freeze()
+
+	defer func() {
+		fmt.Println(recover())
+	}()
+
+	container.Register("plugin", goldi.NewType(NewMockType))
+	// Output:
+	// goldi: could not register type "plugin": goldi: the container has been frozen
+}
+
+var _ = Describe("Container registration", func() {
+	var container *goldi.Container
+
+	BeforeEach(func() {
+		container = goldi.NewContainer(goldi.NewTypeRegistry(), map[string]interface{}{})
+	})
+
+	It("should register types via all registration methods", func() {
+		container.Register("a", goldi.NewType(NewMockType))
+		container.RegisterAll(map[string]goldi.TypeFactory{"b": goldi.NewType(NewFoo)})
+		container.RegisterType("c", NewBar)
+		container.InjectInstance("d", &MockType{})
+		container.InjectOwnedInstance("e", &MockType{})
+
+		Expect(container.TypeRegistry).To(HaveLen(5))
+		for _, typeID := range []string{"a", "b", "c", "d", "e"} {
+			Expect(container.MustGet(typeID)).NotTo(BeNil())
+		}
+	})
+
+	It("should allow registering types while other goroutines retrieve types", func() {
+		container.Register("mock", goldi.NewType(NewMockType))
+
+		var wg sync.WaitGroup
+		for i := 0; i < 20; i++ {
+			wg.Add(2)
+			go func() {
+				defer wg.Done()
+				defer GinkgoRecover()
+				container.Register(fmt.Sprintf("plugin_%d", i), goldi.NewScopedType(goldi.NewType(NewFoo), goldi.Prototype))
+			}()
+			go func() {
+				defer wg.Done()
+				defer GinkgoRecover()
+				Expect(container.MustGet("mock")).NotTo(BeNil())
+				container.FindTaggedTypes("plugin")
+			}()
+		}
+		wg.Wait()
+
+		Expect(container.TypeRegistry).To(HaveLen(21))
+	})
+
+	It("should allow registering types while other goroutines read the registered types", func() {
+		container.Register("mock", goldi.NewType(NewMockType))
+
+		var wg sync.WaitGroup
+		for i := 0; i < 20; i++ {
+			wg.Add(2)
+			go func() {
+				defer wg.Done()
+				defer GinkgoRecover()
+				container.Register(fmt.Sprintf("plugin_%d", i), goldi.NewType(NewFoo))
+			}()
+			go func() {
+				defer wg.Done()
+				defer GinkgoRecover()
+				Expect(container.CollectTypeIDs()).To(ContainElement("mock"))
+				Expect(container.CollectAll()).To(HaveKey("mock"))
+				for typeID := range container.All() {
+					container.Register(typeID+"_alias", goldi.NewAliasType(typeID))
+				}
+				container.AutowireCandidates(reflect.TypeOf(&Foo{}))
+			}()
+		}
+		wg.Wait()
+
+		Expect(container.CollectTypeIDs()).To(ContainElements("mock", "plugin_0", "plugin_19", "mock_alias"))
+	})
+
+	It("should not return the registry of the container from CollectAll", func() {
+		container.Register("mock", goldi.NewType(NewMockType))
+
+		container.CollectAll()["other"] = goldi.NewType(NewFoo)
+		Expect(container.TypeRegistry).NotTo(HaveKey("other"))
+	})
+
+	Describe("Freeze", func() {
+		BeforeEach(func() {
+			container.Register("mock", goldi.NewType(NewMockType))
+			container.Freeze()
+		})
+
+		It("should mark the container as frozen", func() {
+			Expect(container.IsFrozen()).To(BeTrue())
+			Expect(goldi.NewContainer(goldi.NewTypeRegistry(), nil).IsFrozen()).To(BeFalse())
+		})
+
+		It("should still generate the registered types", func() {
+			Expect(container.MustGet("mock")).To(BeAssignableToTypeOf(&MockType{}))
+		})
+
+		It("should panic on any further registration", func() {
+			Expect(func() { container.Register("foo", goldi.NewType(NewFoo)) }).To(PanicWith(MatchError(goldi.ErrFrozen)))
+			Expect(func() { container.RegisterAll(map[string]goldi.TypeFactory{}) }).To(PanicWith(MatchError(goldi.ErrFrozen)))
+			Expect(func() { container.RegisterType("foo", NewFoo) }).To(PanicWith(MatchError(goldi.ErrFrozen)))
+			Expect(func() { container.InjectInstance("foo", &Foo{}) }).To(PanicWith(MatchError(goldi.ErrFrozen)))
+			Expect(func() { container.InjectOwnedInstance("foo", &Foo{}) }).To(PanicWith(MatchError(goldi.ErrFrozen)))
+			Expect(func() { goldi.Provide(container, MockKey, goldi.NewType(NewMockType)) }).To(PanicWith(MatchError(goldi.ErrFrozen)))
+		})
+
+		It("should not be affected by changes to the registry the container has been created with", func() {
+			registry := goldi.NewTypeRegistry()
+			container = goldi.NewContainer(registry, map[string]interface{}{})
+			container.Freeze()
+
+			registry.Register("mock", goldi.NewType(NewMockType))
+			_, err := container.Get("mock")
+			Expect(err).To(MatchError("no such type has been defined"))
+		})
+
+		It("should be idempotent", func() {
+			Expect(container.Freeze).NotTo(Panic())
+			Expect(container.IsFrozen()).To(BeTrue())
+		})
+
+		It("should allow injecting instances into scopes of a frozen container", func() {
+			scope := container.NewScope()
+			scope.InjectInstance("request", "GET /")
+			Expect(scope.MustGet("request")).To(Equal("GET /"))
+			Expect(scope.MustGet("mock")).To(BeIdenticalTo(container.MustGet("mock")))
+		})
+	})
+
+	Describe("Clone", func() {
+		It("should return a mutable copy of a frozen container", func() {
+			container.Register("mock", goldi.NewType(NewMockType))
+			container.Freeze()
+
+			clone := container.Clone()
+			Expect(clone.IsFrozen()).To(BeFalse())
+			clone.Register("foo", goldi.NewType(NewFoo))
+
+			Expect(clone.MustGet("foo")).To(BeAssignableToTypeOf(&Foo{}))
+			Expect(container.TypeRegistry).NotTo(HaveKey("foo"))
+		})
+
+		It("should not share any instances", func() {
+			container.Register("mock", goldi.NewType(NewMockType))
+			clone := container.Clone()
+
+			Expect(clone.MustGet("mock")).NotTo(BeIdenticalTo(container.MustGet("mock")))
+			Expect(clone.Config).To(Equal(container.Config))
+		})
+	})
+})
@@ -1,7 +1,13 @@
 package goldi_test
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
@@ -17,6 +23,59 @@ func NewNullLogger() *NullLogger {
 	return &NullLogger{}
 }
 
+type contextKey string
+
+// ContextAwareClient is a test type that is created using a context
+type ContextAwareClient struct {
+	Ctx     context.Context
+	Address string
+}
+
+func NewContextAwareClient(ctx context.Context, address string) (*ContextAwareClient, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	return &ContextAwareClient{Ctx: ctx, Address: address}, nil
+}
+
+// Node is a test type that can be used to build dependency chains
+type Node struct {
+	Next *Node
+}
+
+func NewNodeWithCallback(callback func()) *Node {
+	return &Node{}
+}
+
+func (n *Node) Configure(other *Node) {}
+
+func (n *Node) NewNode() *Node {
+	return &Node{Next: n}
+}
+
+func (n *Node) Do() {}
+
+// getConcurrently requests the given type from n goroutines at the same time and returns all results
+func getConcurrently(container *goldi.Container, typeID string, n int) []interface{} {
+	var wg sync.WaitGroup
+	start := make(chan struct{})
+	results := make([]interface{}, n)
+	for i := range n {
+		wg.Add(1)
+		go func() {
+			defer GinkgoRecover()
+			defer wg.Done()
+			<-start
+			results[i] = container.MustGet(typeID)
+		}()
+	}
+
+	close(start)
+	wg.Wait()
+	return results
+}
+
 func ExampleContainer() {
 	registry := goldi.NewTypeRegistry()
 	config := map[string]interface{}{}
@@ -108,6 +167,27 @@ var _ = Describe("Container", func() {
 		Expect(firstResult == thirdResult).To(BeTrue())
 	})
 
+	It("should build prototype types on each request", func() {
+		generator := &MockTypeFactory{}
+		registry.Register("test_type", goldi.NewScopedType(goldi.NewType(generator.NewMockType), goldi.Prototype))
+
+		firstResult := container.MustGet("test_type")
+		secondResult := container.MustGet("test_type")
+		Expect(firstResult).To(BeAssignableToTypeOf(&MockType{}))
+		Expect(firstResult == secondResult).To(BeFalse())
+		Expect(container.CollectCachedTypeIDs()).NotTo(ContainElement("test_type"))
+	})
+
+	It("should inject the cached singletons into prototypes", func() {
+		registry.RegisterType("foo", NewMockType)
+		registry.Register("test_type", goldi.NewScopedType(goldi.NewType(NewTypeForServiceInjection, "@foo"), goldi.Prototype))
+
+		firstResult := container.MustGet("test_type").(*TypeForServiceInjection)
+		secondResult := container.MustGet("test_type").(*TypeForServiceInjection)
+		Expect(firstResult == secondResult).To(BeFalse())
+		Expect(firstResult.InjectedType == secondResult.InjectedType).To(BeTrue())
+	})
+
 	It("should pass static parameters as arguments when generating types", func() {
 		typeID := "test_type"
 		typeDef := goldi.NewType(NewMockTypeWithArgs, "parameter1", true)
@@ -177,4 +257,353 @@ var _ = Describe("Container", func() {
 		generatedMock := generatedType.(*TypeForServiceInjection)
 		Expect(generatedMock.InjectedType).To(BeNil())
 	})
+
+	Describe("GetContext", func() {
+		var ctx context.Context
+
+		BeforeEach(func() {
+			ctx = context.WithValue(context.Background(), contextKey("request_id"), "abc")
+		})
+
+		It("should pass the context to the factory functions", func() {
+			registry.RegisterType("client", NewContextAwareClient, "localhost")
+
+			client, err := container.GetContext(ctx, "client")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(client.(*ContextAwareClient).Ctx).To(BeIdenticalTo(ctx))
+			Expect(client.(*ContextAwareClient).Address).To(Equal("localhost"))
+		})
+
+		It("should pass the context to the factory functions of all dependencies", func() {
+			registry.RegisterType("client", NewContextAwareClient, "localhost")
+			registry.RegisterType("service", func(ctx context.Context, client *ContextAwareClient) *ContextAwareClient {
+				return &ContextAwareClient{Ctx: ctx, Address: client.Address}
+			}, "@client")
+
+			service := container.MustGet("service")
+			Expect(service.(*ContextAwareClient).Ctx).To(Equal(context.Background()))
+			Expect(container.MustGet("client").(*ContextAwareClient).Ctx).To(Equal(context.Background()))
+		})
+
+		It("should support variadic factory functions", func() {
+			registry.RegisterType("client", func(ctx context.Context, addresses ...string) *ContextAwareClient {
+				return &ContextAwareClient{Ctx: ctx, Address: strings.Join(addresses, ",")}
+			}, "a", "b")
+
+			client, err := container.GetContext(ctx, "client")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(client.(*ContextAwareClient).Ctx).To(BeIdenticalTo(ctx))
+			Expect(client.(*ContextAwareClient).Address).To(Equal("a,b"))
+		})
+
+		It("should not generate any type if the context is done", func() {
+			registry.RegisterType("client", NewContextAwareClient, "localhost")
+			canceled, cancel := context.WithCancel(ctx)
+			cancel()
+
+			_, err := container.GetContext(canceled, "client")
+			Expect(err).To(MatchError(`goldi: aborted generating type "client": context canceled`))
+			Expect(errors.Is(err, context.Canceled)).To(BeTrue())
+			Expect(container.CollectCachedTypeIDs()).To(BeEmpty())
+		})
+
+		It("should stop the resolution chain if the context is canceled", func() {
+			canceled, cancel := context.WithCancel(ctx)
+			registry.RegisterType("slow", func(ctx context.Context) *MockType {
+				cancel() // e.g. a shutdown signal while a slow constructor is running
+				return &MockType{}
+			})
+			registry.RegisterType("service", NewTypeForServiceInjection, "@slow")
+			registry.RegisterType("main", func(s *TypeForServiceInjection) *Node { return &Node{} }, "@service")
+
+			_, err := container.GetContext(canceled, "main")
+			Expect(errors.Is(err, context.Canceled)).To(BeTrue())
+			Expect(container.CollectCachedTypeIDs()).To(ConsistOf("slow"))
+		})
+
+		It("should stop waiting for a type that is generated by another goroutine if the context is done", func() {
+			generating, release := make(chan struct{}), make(chan struct{})
+			registry.RegisterType("slow", func() *MockType {
+				close(generating)
+				<-release
+				return &MockType{}
+			})
+
+			go func() {
+				defer GinkgoRecover()
+				container.MustGet("slow")
+			}()
+			<-generating
+
+			timeout, cancel := context.WithTimeout(ctx, 10*time.Millisecond)
+			defer cancel()
+
+			_, err := container.GetContext(timeout, "slow")
+			Expect(err).To(MatchError(`goldi: aborted waiting for type "slow": context deadline exceeded`))
+			close(release)
+		})
+
+		It("should retrieve the type using the generic GetContext", func() {
+			registry.RegisterType("client", NewContextAwareClient, "localhost")
+
+			client, err := goldi.GetContext[*ContextAwareClient](ctx, container, "client")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(client.Ctx).To(BeIdenticalTo(ctx))
+
+			_, err = goldi.GetContext[*MockType](ctx, container, "client")
+			Expect(err).To(MatchError(`goldi: type "client" cannot be asserted to *goldi_test.MockType`))
+		})
+	})
+
+	Describe("concurrent Get", func() {
+		var calls atomic.Int32
+
+		slowFactory := func(next *Node) *Node {
+			calls.Add(1)
+			time.Sleep(time.Millisecond)
+			return &Node{Next: next}
+		}
+
+		BeforeEach(func() {
+			calls.Store(0)
+		})
+
+		It("should generate a cold type only once", func() {
+			registry.RegisterType("node", slowFactory, "@?none")
+
+			results := getConcurrently(container, "node", 50)
+			Expect(calls.Load()).To(BeEquivalentTo(1))
+			for _, result := range results {
+				Expect(result).To(BeIdenticalTo(results[0]))
+			}
+			Expect(container.CollectCachedTypeIDs()).To(ConsistOf("node"))
+		})
+
+		It("should generate each dependency only once", func() {
+			registry.RegisterType("a", slowFactory, "@?none")
+			registry.RegisterType("b", slowFactory, "@a")
+			registry.RegisterType("c", slowFactory, "@b")
+			registry.RegisterType("d", slowFactory, "@a")
+
+			var wg sync.WaitGroup
+			for _, typeID := range []string{"a", "b", "c", "d", "c", "b", "d", "a"} {
+				wg.Add(1)
+				go func() {
+					defer GinkgoRecover()
+					defer wg.Done()
+					getConcurrently(container, typeID, 10)
+				}()
+			}
+
+			wg.Wait()
+			Expect(calls.Load()).To(BeEquivalentTo(4))
+			Expect(container.MustGet("c").(*Node).Next.Next).To(BeIdenticalTo(container.MustGet("d").(*Node).Next))
+		})
+
+		It("should generate singletons only once if they are requested from different scopes", func() {
+			registry.RegisterType("node", slowFactory, "@?none")
+			scopes := []*goldi.Container{container.NewScope(), container.NewScope(), container.NewScope()}
+
+			var wg sync.WaitGroup
+			for _, scope := range scopes {
+				wg.Add(1)
+				go func() {
+					defer GinkgoRecover()
+					defer wg.Done()
+					getConcurrently(scope, "node", 10)
+				}()
+			}
+
+			wg.Wait()
+			Expect(calls.Load()).To(BeEquivalentTo(1))
+		})
+
+		It("should generate scoped types once per scope", func() {
+			registry.Register("node", goldi.NewScopedType(goldi.NewType(slowFactory, "@?none"), goldi.Scoped))
+			scope := container.NewScope()
+
+			getConcurrently(scope, "node", 20)
+			getConcurrently(container.NewScope(), "node", 20)
+			Expect(calls.Load()).To(BeEquivalentTo(2))
+		})
+
+		It("should return an error instead of blocking forever if a type depends on itself", func() {
+			registry.RegisterType("a", slowFactory, "@b")
+			registry.RegisterType("b", slowFactory, "@a")
+
+			_, err := container.Get("a")
+			Expect(err).To(MatchError("goldi: circular dependency detected: a -> b -> a"))
+		})
+
+		It("should not deadlock if goroutines wait for each other", func() {
+			registry.RegisterType("a", slowFactory, "@b")
+			registry.RegisterType("b", slowFactory, "@a")
+
+			var wg sync.WaitGroup
+			for i := range 20 {
+				wg.Add(1)
+				go func() {
+					defer GinkgoRecover()
+					defer wg.Done()
+					_, err := container.Get([]string{"a", "b"}[i%2])
+
+					var circularDependency goldi.CircularDependencyError
+					Expect(errors.As(err, &circularDependency)).To(BeTrue())
+					Expect(circularDependency.Path).To(Or(
+						Equal([]string{"a", "b", "a"}),
+						Equal([]string{"b", "a", "b"}),
+					))
+				}()
+			}
+
+			wg.Wait()
+		})
+	})
+
+	Describe("circular dependencies", func() {
+		cycleOf := func(typeID string) []string {
+			_, err := container.Get(typeID)
+
+			var circularDependency goldi.CircularDependencyError
+			Expect(errors.As(err, &circularDependency)).To(BeTrue(), "expected a CircularDependencyError but got %v", err)
+			return circularDependency.Path
+		}
+
+		It("should return the full path of the cycle", func() {
+			registry.RegisterType("a", &Node{}, "@b")
+			registry.RegisterType("b", &Node{}, "@c")
+			registry.RegisterType("c", &Node{}, "@a")
+
+			_, err := container.Get("a")
+			Expect(err).To(MatchError("goldi: circular dependency detected: a -> b -> c -> a"))
+			Expect(cycleOf("b")).To(Equal([]string{"b", "c", "a", "b"}))
+		})
+
+		It("should only include the types of the cycle in the path", func() {
+			registry.RegisterType("main", &Node{}, "@a")
+			registry.RegisterType("a", &Node{}, "@b")
+			registry.RegisterType("b", &Node{}, "@a")
+
+			Expect(cycleOf("main")).To(Equal([]string{"a", "b", "a"}))
+		})
+
+		It("should detect types that depend on themselves", func() {
+			registry.RegisterType("a", &Node{}, "@a")
+			Expect(cycleOf("a")).To(Equal([]string{"a", "a"}))
+		})
+
+		It("should detect aliases that point to themselves", func() {
+			registry.Register("a", goldi.NewAliasType("a"))
+			Expect(cycleOf("a")).To(Equal([]string{"a", "a"}))
+		})
+
+		It("should detect cycles of prototypes", func() {
+			registry.Register("a", goldi.NewScopedType(goldi.NewStructType(&Node{}, "@b"), goldi.Prototype))
+			registry.Register("b", goldi.NewScopedType(goldi.NewStructType(&Node{}, "@a"), goldi.Prototype))
+			Expect(cycleOf("a")).To(Equal([]string{"a", "b", "a"}))
+		})
+
+		It("should detect cycles through proxy types", func() {
+			registry.Register("a", goldi.NewProxyType("b", "NewNode"))
+			registry.RegisterType("b", &Node{}, "@a")
+			Expect(cycleOf("a")).To(Equal([]string{"a", "b", "a"}))
+		})
+
+		It("should detect cycles through func reference types", func() {
+			registry.Register("a", goldi.NewFuncReferenceType("b", "Do"))
+			registry.RegisterType("b", NewNodeWithCallback, "@a")
+			Expect(cycleOf("a")).To(Equal([]string{"a", "b", "a"}))
+		})
+
+		It("should detect cycles through type configurators", func() {
+			registry.Register("a", goldi.NewConfiguredType(goldi.NewStructType(&Node{}), "b", "Configure"))
+			registry.RegisterType("b", &Node{}, "@a")
+			Expect(cycleOf("a")).To(Equal([]string{"a", "b", "a"}))
+		})
+
+		It("should detect cycles through the types of a parent container", func() {
+			registry.RegisterType("a", &Node{}, "@b")
+			registry.Register("b", goldi.NewScopedType(goldi.NewStructType(&Node{}, "@a"), goldi.Scoped))
+
+			_, err := container.NewScope().Get("b")
+			Expect(err).To(HaveOccurred())
+			Expect(cycleOf("a")).To(Equal([]string{"a", "b", "a"}))
+		})
+
+		It("should not treat the same type in different branches as cycle", func() {
+			registry.Register("shared", goldi.NewScopedType(goldi.NewStructType(&Node{}, "@?none"), goldi.Prototype))
+			registry.RegisterType("a", &Node{}, "@shared")
+			registry.RegisterType("b", &Node{}, "@shared")
+			registry.RegisterType("main", func(a, b *Node) *Node { return &Node{} }, "@a", "@b")
+
+			_, err := container.Get("main")
+			Expect(err).NotTo(HaveOccurred())
+		})
+	})
+
+	Describe("NewScope", func() {
+		var scope *goldi.Container
+
+		BeforeEach(func() {
+			scope = container.NewScope()
+		})
+
+		It("should return a child container", func() {
+			Expect(scope).NotTo(BeIdenticalTo(container))
+			Expect(scope.Parent()).To(BeIdenticalTo(container))
+			Expect(container.Parent()).To(BeNil())
+		})
+
+		It("should return the singletons of the parent", func() {
+			registry.RegisterType("foo", NewMockType)
+
+			Expect(scope.MustGet("foo") == container.MustGet("foo")).To(BeTrue())
+			Expect(scope.CollectCachedTypeIDs()).To(BeEmpty())
+		})
+
+		It("should cache scoped types once per scope", func() {
+			registry.Register("foo", goldi.NewScopedType(goldi.NewType(NewMockType), goldi.Scoped))
+			otherScope := container.NewScope()
+
+			Expect(scope.MustGet("foo") == scope.MustGet("foo")).To(BeTrue())
+			Expect(scope.MustGet("foo") == otherScope.MustGet("foo")).To(BeFalse())
+			Expect(container.CollectCachedTypeIDs()).NotTo(ContainElement("foo"))
+		})
+
+		It("should resolve type references in the scope first", func() {
+			requestValue := &MockType{StringParameter: "request"}
+			registry.InjectInstance("request", &MockType{StringParameter: "default"})
+			registry.Register("handler", goldi.NewScopedType(goldi.NewType(NewTypeForServiceInjection, "@request"), goldi.Scoped))
+			scope.InjectInstance("request", requestValue)
+
+			Expect(scope.MustGet("handler").(*TypeForServiceInjection).InjectedType).To(BeIdenticalTo(requestValue))
+			Expect(container.MustGet("handler").(*TypeForServiceInjection).InjectedType.StringParameter).To(Equal("default"))
+		})
+
+		It("should not expose the instances injected into the scope to the parent", func() {
+			scope.InjectInstance("request", &MockType{})
+			_, err := container.Get("request")
+			Expect(err).To(MatchError("no such type has been defined"))
+		})
+
+		It("should generate singletons using the registry of their container", func() {
+			registry.Register("handler", goldi.NewType(NewTypeForServiceInjection, "@?request"))
+			scope.InjectInstance("request", &MockType{})
+
+			Expect(scope.MustGet("handler").(*TypeForServiceInjection).InjectedType).To(BeNil())
+		})
+
+		It("should release the scoped instances when the scope is closed", func() {
+			registry.RegisterType("singleton", NewMockType)
+			registry.Register("foo", goldi.NewScopedType(goldi.NewType(NewMockType), goldi.Scoped))
+
+			first := scope.MustGet("foo")
+			scope.MustGet("singleton")
+			Expect(scope.Close(context.Background())).To(Succeed())
+
+			Expect(scope.CollectCachedTypeIDs()).To(BeEmpty())
+			Expect(container.CollectCachedTypeIDs()).To(ConsistOf("singleton"))
+			Expect(scope.MustGet("foo") == first).To(BeFalse())
+		})
+	})
 })
@@ -0,0 +1,242 @@
+package goldi
+
+import (
+	"encoding"
+	"fmt"
+	"math"
+	"net/url"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	durationType        = reflect.TypeOf(time.Duration(0))
+	urlType             = reflect.TypeOf(url.URL{})
+	textUnmarshalerType = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+)
+
+// convertValue converts a configured parameter value into the expected type.
+//
+// Values that are assignable to the expected type are used as is. Otherwise the value is coerced:
+//   - numbers are converted into other numeric types if the value fits into the expected type without losing
+//     precision, so values decoded from JSON (which are always float64) can be used for int arguments
+//   - strings are parsed into bools, numbers, time.Duration, url.URL and all types that implement
+//     encoding.TextUnmarshaler
+//   - nested configuration values are converted recursively which means that maps can be decoded into structs (or
+//     pointers to structs), maps with interface{} keys into maps with string keys and slices of interface{} into
+//     typed slices
+func convertValue(value reflect.Value, expectedType reflect.Type) (reflect.Value, error) {
+	for value.IsValid() && value.Kind() == reflect.Interface {
+		value = value.Elem()
+	}
+
+	if value.IsValid() == false {
+		switch expectedType.Kind() {
+		case reflect.Chan, reflect.Func, reflect.Interface, reflect.Map, reflect.Ptr, reflect.Slice:
+			return reflect.Zero(expectedType), nil
+		default:
+			return reflect.Value{}, fmt.Errorf("can not use nil as %v", expectedType)
+		}
+	}
+
+	if value.Type().AssignableTo(expectedType) {
+		result := reflect.New(expectedType).Elem()
+		result.Set(value)
+		return result, nil
+	}
+
+	if value.Kind() == reflect.String {
+		if result, isParsed, err := parseString(value.String(), expectedType); isParsed {
+			return result, err
+		}
+	}
+
+	switch {
+	case expectedType.Kind() == reflect.Ptr && expectedType.Elem().Kind() == reflect.Struct && value.Kind() == reflect.Map:
+		decoded, err := decodeStruct(value, expectedType.Elem())
+		if err != nil {
+			return reflect.Value{}, err
+		}
+
+		result := reflect.New(expectedType.Elem())
+		result.Elem().Set(decoded)
+		return result, nil
+	case expectedType.Kind() == reflect.Struct && value.Kind() == reflect.Map:
+		return decodeStruct(value, expectedType)
+	case expectedType.Kind() == reflect.Map && value.Kind() == reflect.Map:
+		return convertMap(value, expectedType)
+	case expectedType.Kind() == reflect.Slice && (value.Kind() == reflect.Slice || value.Kind() == reflect.Array):
+		return convertSlice(value, expectedType)
+	case isNumber(expectedType.Kind()) && isNumber(value.Kind()):
+		return convertNumber(value, expectedType)
+	case expectedType.Kind() == reflect.String && value.Kind() == reflect.String:
+		return value.Convert(expectedType), nil
+	default:
+		return reflect.Value{}, fmt.Errorf("can not use %v (type %v) as %v", value.Interface(), value.Type(), expectedType)
+	}
+}
+
+// decodeStruct decodes the given map into a new struct of the given type.
+// The keys of the map are matched against the exported field names of the struct ignoring case, underscores and
+// dashes so "max_connections" can be decoded into a field "MaxConnections".
+func decodeStruct(value reflect.Value, structType reflect.Type) (reflect.Value, error) {
+	result := reflect.New(structType).Elem()
+	iter := value.MapRange()
+	for iter.Next() {
+		key := fmt.Sprint(iter.Key().Interface())
+		field, hasField := structType.FieldByNameFunc(func(fieldName string) bool {
+			return normalizeFieldName(fieldName) == normalizeFieldName(key)
+		})
+
+		if hasField == false || field.IsExported() == false {
+			return reflect.Value{}, fmt.Errorf("can not decode %q into %v: the struct has no exported field with that name", key, structType)
+		}
+
+		fieldValue, err := convertValue(iter.Value(), field.Type)
+		if err != nil {
+			return reflect.Value{}, fmt.Errorf("can not decode %q into %v: %w", key, structType, err)
+		}
+
+		result.FieldByIndex(field.Index).Set(fieldValue)
+	}
+
+	return result, nil
+}
+
+func normalizeFieldName(name string) string {
+	return strings.ToLower(strings.NewReplacer("_", "", "-", "").Replace(name))
+}
+
+func convertMap(value reflect.Value, mapType reflect.Type) (reflect.Value, error) {
+	result := reflect.MakeMapWithSize(mapType, value.Len())
+	iter := value.MapRange()
+	for iter.Next() {
+		key, err := convertValue(iter.Key(), mapType.Key())
+		if err != nil && mapType.Key().Kind() == reflect.String {
+			key, err = reflect.ValueOf(fmt.Sprint(iter.Key().Interface())).Convert(mapType.Key()), nil
+		}
+		if err != nil {
+			return reflect.Value{}, fmt.Errorf("invalid map key %v: %w", iter.Key().Interface(), err)
+		}
+
+		element, err := convertValue(iter.Value(), mapType.Elem())
+		if err != nil {
+			return reflect.Value{}, fmt.Errorf("invalid value of map key %v: %w", iter.Key().Interface(), err)
+		}
+
+		result.SetMapIndex(key, element)
+	}
+
+	return result, nil
+}
+
+func convertSlice(value reflect.Value, sliceType reflect.Type) (reflect.Value, error) {
+	result := reflect.MakeSlice(sliceType, value.Len(), value.Len())
+	for i := 0; i < value.Len(); i++ {
+		element, err := convertValue(value.Index(i), sliceType.Elem())
+		if err != nil {
+			return reflect.Value{}, fmt.Errorf("invalid element %d: %w", i, err)
+		}
+
+		result.Index(i).Set(element)
+	}
+
+	return result, nil
+}
+
+// convertNumber converts a number into another numeric type.
+// An error is returned if the number overflows the expected type or a float with a fraction should be converted
+// into an integer.
+func convertNumber(value reflect.Value, expectedType reflect.Type) (reflect.Value, error) {
+	result := reflect.New(expectedType).Elem()
+	var overflows bool
+	switch {
+	case value.CanInt() && result.CanInt():
+		overflows = result.OverflowInt(value.Int())
+	case value.CanInt() && result.CanUint():
+		overflows = value.Int() < 0 || result.OverflowUint(uint64(value.Int()))
+	case value.CanUint() && result.CanInt():
+		overflows = value.Uint() > math.MaxInt64 || result.OverflowInt(int64(value.Uint()))
+	case value.CanUint() && result.CanUint():
+		overflows = result.OverflowUint(value.Uint())
+	case value.CanFloat() && result.CanFloat():
+		overflows = result.OverflowFloat(value.Float())
+	case value.CanFloat():
+		f := value.Float()
+		if f != math.Trunc(f) {
+			return reflect.Value{}, fmt.Errorf("can not use %v (type %v) as %v without losing its fraction", value.Interface(), value.Type(), expectedType)
+		}
+		overflows = f < math.MinInt64 || f >= math.MaxInt64 || (result.CanUint() && f < 0) ||
+			(result.CanInt() && result.OverflowInt(int64(f))) || (result.CanUint() && result.OverflowUint(uint64(f)))
+	}
+
+	if overflows {
+		return reflect.Value{}, fmt.Errorf("%v (type %v) overflows %v", value.Interface(), value.Type(), expectedType)
+	}
+
+	result.Set(value.Convert(expectedType))
+	return result, nil
+}
+
+// parseString parses the given string into the expected type.
+// The returned bool is false if the expected type can not be parsed from a string at all.
+func parseString(s string, expectedType reflect.Type) (reflect.Value, bool, error) {
+	result := reflect.New(expectedType).Elem()
+
+	var err error
+	switch {
+	case expectedType.Implements(textUnmarshalerType) && expectedType.Kind() == reflect.Ptr:
+		result = reflect.New(expectedType.Elem())
+		err = result.Interface().(encoding.TextUnmarshaler).UnmarshalText([]byte(s))
+	case reflect.PointerTo(expectedType).Implements(textUnmarshalerType):
+		err = result.Addr().Interface().(encoding.TextUnmarshaler).UnmarshalText([]byte(s))
+	case expectedType == durationType:
+		var duration time.Duration
+		duration, err = time.ParseDuration(s)
+		result.SetInt(int64(duration))
+	case expectedType == urlType || expectedType == reflect.PointerTo(urlType):
+		var u *url.URL
+		if u, err = url.Parse(s); err == nil && expectedType == urlType {
+			result.Set(reflect.ValueOf(*u))
+		} else if err == nil {
+			result.Set(reflect.ValueOf(u))
+		}
+	case expectedType.Kind() == reflect.Bool:
+		var b bool
+		b, err = strconv.ParseBool(s)
+		result.SetBool(b)
+	case result.CanInt():
+		var i int64
+		i, err = strconv.ParseInt(s, 0, expectedType.Bits())
+		result.SetInt(i)
+	case result.CanUint():
+		var u uint64
+		u, err = strconv.ParseUint(s, 0, expectedType.Bits())
+		result.SetUint(u)
+	case result.CanFloat():
+		var f float64
+		f, err = strconv.ParseFloat(s, expectedType.Bits())
+		result.SetFloat(f)
+	default:
+		return reflect.Value{}, false, nil
+	}
+
+	if err != nil {
+		return reflect.Value{}, true, err
+	}
+
+	return result, true, nil
+}
+
+func isNumber(kind reflect.Kind) bool {
+	switch kind {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return true
+	default:
+		return false
+	}
+}
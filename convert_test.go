@@ -0,0 +1,155 @@
+package goldi_test
+
+import (
+	"errors"
+	"net"
+	"net/url"
+	"reflect"
+	"strconv"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/tarokamikaze/goldi"
+)
+
+func NewServerConfig(host string, port int, timeout time.Duration) *ServerConfig {
+	return &ServerConfig{Host: host, Port: port, Timeout: timeout}
+}
+
+type ServerConfig struct {
+	Host    string
+	Port    int
+	Timeout time.Duration
+}
+
+var _ = Describe("parameter type coercion", func() {
+	var (
+		config   map[string]interface{}
+		resolver *goldi.ParameterResolver
+	)
+
+	BeforeEach(func() {
+		config = map[string]interface{}{}
+		resolver = goldi.NewParameterResolver(goldi.NewContainer(goldi.NewTypeRegistry(), config))
+	})
+
+	convert := func(value interface{}, expected interface{}) (interface{}, error) {
+		config["value"] = value
+		result, err := resolver.Resolve(reflect.ValueOf("%value%"), reflect.TypeOf(expected))
+		if err != nil {
+			return nil, err
+		}
+		return result.Interface(), nil
+	}
+
+	Describe("numbers", func() {
+		It("should convert numbers without losing precision", func() {
+			Expect(convert(8080.0, int(0))).To(Equal(8080))
+			Expect(convert(42, int8(0))).To(Equal(int8(42)))
+			Expect(convert(42, uint16(0))).To(Equal(uint16(42)))
+			Expect(convert(uint(42), int64(0))).To(Equal(int64(42)))
+			Expect(convert(42, 0.0)).To(Equal(42.0))
+			Expect(convert(0.5, float32(0))).To(Equal(float32(0.5)))
+		})
+
+		It("should return an error if the number overflows the expected type", func() {
+			_, err := convert(300, int8(0))
+			Expect(err).To(MatchError(ContainSubstring("300 (type int) overflows int8")))
+
+			_, err = convert(-1, uint(0))
+			Expect(err).To(MatchError(ContainSubstring("-1 (type int) overflows uint")))
+
+			_, err = convert(1e20, int64(0))
+			Expect(err).To(MatchError(ContainSubstring("overflows int64")))
+		})
+
+		It("should return an error if a fraction would be lost", func() {
+			_, err := convert(1.5, int(0))
+			Expect(err).To(MatchError(ContainSubstring("can not use 1.5 (type float64) as int without losing its fraction")))
+		})
+	})
+
+	Describe("strings", func() {
+		It("should parse bools and numbers", func() {
+			Expect(convert("true", false)).To(Equal(true))
+			Expect(convert("8080", int(0))).To(Equal(8080))
+			Expect(convert("0x10", uint8(0))).To(Equal(uint8(16)))
+			Expect(convert("0.25", 0.0)).To(Equal(0.25))
+		})
+
+		It("should parse durations", func() {
+			Expect(convert("1m30s", time.Duration(0))).To(Equal(90 * time.Second))
+		})
+
+		It("should parse URLs", func() {
+			u, err := convert("https://example.com/api", &url.URL{})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(u.(*url.URL).Host).To(Equal("example.com"))
+
+			Expect(convert("https://example.com/api", url.URL{})).To(BeAssignableToTypeOf(url.URL{}))
+		})
+
+		It("should use encoding.TextUnmarshaler implementations", func() {
+			Expect(convert("127.0.0.1", net.IP{})).To(Equal(net.ParseIP("127.0.0.1")))
+			Expect(convert("2024-01-02T03:04:05Z", time.Time{})).To(Equal(time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)))
+		})
+
+		It("should convert strings into named string types", func() {
+			type Environment string
+			Expect(convert("production", Environment(""))).To(Equal(Environment("production")))
+		})
+
+		It("should return an error if a string can not be parsed", func() {
+			_, err := convert("http", int(0))
+			Expect(errors.Is(err, strconv.ErrSyntax)).To(BeTrue())
+
+			_, err = convert("soon", time.Duration(0))
+			Expect(err).To(MatchError(ContainSubstring(`time: invalid duration "soon"`)))
+		})
+	})
+
+	Describe("slices", func() {
+		It("should convert []interface{} into typed slices", func() {
+			Expect(convert([]interface{}{"1s", "2s"}, []time.Duration{})).To(Equal([]time.Duration{time.Second, 2 * time.Second}))
+		})
+
+		It("should return an error if an element can not be converted", func() {
+			_, err := convert([]interface{}{1, "two"}, []int{})
+			Expect(err).To(MatchError(ContainSubstring("invalid element 1")))
+		})
+	})
+
+	Describe("ParameterError", func() {
+		It("should name the parameter, the type ID and the argument", func() {
+			config["server.host"] = "localhost"
+			config["server.port"] = "http"
+			config["server.timeout"] = "1s"
+			container := goldi.NewContainer(goldi.NewTypeRegistry(), config)
+			container.RegisterType("server_config", NewServerConfig, "%server.host%", "%server.port%", "%server.timeout%")
+
+			_, err := container.Get("server_config")
+			Expect(err).To(MatchError(`goldi: can not use parameter "%server.port%" (value "http") as int for argument 2 of type "server_config": strconv.ParseInt: parsing "http": invalid syntax`))
+
+			var parameterErr *goldi.ParameterError
+			Expect(errors.As(err, &parameterErr)).To(BeTrue())
+			Expect(parameterErr.TypeID).To(Equal("server_config"))
+			Expect(parameterErr.ArgumentIndex).To(Equal(1))
+			Expect(parameterErr.ExpectedType).To(Equal(reflect.TypeOf(0)))
+		})
+
+		It("should not change the type ID of errors of referenced types", func() {
+			config["server.port"] = 1.5
+			config["server.timeout"] = "1s"
+			container := goldi.NewContainer(goldi.NewTypeRegistry(), config)
+			container.RegisterType("server_config", NewServerConfig, "localhost", "%server.port%", "%server.timeout%")
+			container.RegisterType("server", NewTypeForServiceInjectionWithArgs, "@server_config", "a", "b", true)
+
+			_, err := container.Get("server")
+			var parameterErr *goldi.ParameterError
+			Expect(errors.As(err, &parameterErr)).To(BeTrue())
+			Expect(parameterErr.TypeID).To(Equal("server_config"))
+			Expect(parameterErr.ArgumentIndex).To(Equal(1))
+		})
+	})
+})
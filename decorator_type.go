@@ -0,0 +1,183 @@
+package goldi
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// InnerTypeID is the type ID that decorators use to reference the instance they decorate (e.g. "@.inner").
+const InnerTypeID = ".inner"
+
+type decoratorType struct {
+	TypeFactory
+	decoratedTypeID string
+	priority        int
+}
+
+// NewDecoratorType creates a new TypeFactory that decorates the type with the given ID.
+// Once a decorator has been registered, the container returns the result of the decorator whenever the decorated
+// type is requested. The decorator gets the original instance injected via the "@.inner" type reference
+// (see InnerTypeID) and usually returns a wrapped version of it.
+//
+// Multiple decorators of the same type are applied in the order of their priority. The decorator with the highest
+// priority is applied first which means it receives the original instance. Decorators with equal priority are
+// applied in the alphabetical order of their type IDs. Requesting the type ID of a decorator itself returns the
+// fully decorated type.
+//
+// NewDecoratorType will return an invalid type when embeddedType is nil or the decorated type ID is empty.
+//
+// Goldigen yaml syntax example:
+//
+//	tracing_logger:
+//	    package:             github.com/fgrosse/foobar
+//	    factory:             NewTracingLogger
+//	    args:                [ "@.inner" ]
+//	    decorates:           logger
+//	    decoration_priority: 10
+func NewDecoratorType(embeddedType TypeFactory, decoratedTypeID string, priority int) TypeFactory {
+	if embeddedType == nil {
+		return newInvalidType(fmt.Errorf("refusing to create a new DecoratorType with nil as embedded type"))
+	}
+
+	decoratedTypeID = strings.TrimPrefix(strings.TrimSpace(decoratedTypeID), "@")
+	if decoratedTypeID == "" {
+		return newInvalidType(fmt.Errorf("can not create a new DecoratorType with an empty decorated type ID"))
+	}
+
+	return &decoratorType{
+		TypeFactory:     embeddedType,
+		decoratedTypeID: decoratedTypeID,
+		priority:        priority,
+	}
+}
+
+// Arguments returns the arguments of the embedded type and a reference to the decorated type.
+func (t *decoratorType) Arguments() []interface{} {
+	return append(t.TypeFactory.Arguments(), "@"+t.decoratedTypeID)
+}
+
+func (t *decoratorType) unwrap() TypeFactory {
+	return t.TypeFactory
+}
+
+// A decoratedType generates a type and applies all its decorators to the result.
+type decoratedType struct {
+	TypeFactory
+	decorators []*decoratorType
+}
+
+// A decoratorIndex maps the IDs of decorated types to the IDs of their decorators in the order in which they are
+// applied. It is built once from the registry of a container so lookups do not have to scan the whole registry.
+type decoratorIndex struct {
+	registrySize int
+	replacements uint64 // the value of registryReplacements before the index has been built
+	decorators   map[string][]string
+}
+
+func newDecoratorIndex(registry TypeRegistry, replacements uint64) *decoratorIndex {
+	type decoratorWithID struct {
+		typeID string
+		*decoratorType
+	}
+
+	decorators := map[string][]decoratorWithID{}
+	for decoratorTypeID, factory := range registry {
+		if decorator, isDecorator := findEmbedded[*decoratorType](factory); isDecorator {
+			decorators[decorator.decoratedTypeID] = append(decorators[decorator.decoratedTypeID], decoratorWithID{decoratorTypeID, decorator})
+		}
+	}
+
+	index := &decoratorIndex{registrySize: len(registry), replacements: replacements, decorators: make(map[string][]string, len(decorators))}
+	for decoratedTypeID, typeDecorators := range decorators {
+		slices.SortFunc(typeDecorators, func(a, b decoratorWithID) int {
+			if a.priority != b.priority {
+				return cmp.Compare(b.priority, a.priority)
+			}
+
+			return strings.Compare(a.typeID, b.typeID)
+		})
+
+		for _, decorator := range typeDecorators {
+			index.decorators[decoratedTypeID] = append(index.decorators[decoratedTypeID], decorator.typeID)
+		}
+	}
+
+	return index
+}
+
+// decorate returns a TypeFactory that applies all decorators of the given type that are registered in the registry.
+// If there are no decorators the given TypeFactory is returned as is. The registry must be the registry of c.
+//
+// The decorators are looked up in an index that is rebuilt whenever types are registered via the container, the
+// number of types in the registry has changed or an existing type has been replaced via TypeRegistry.Register.
+func (c *Container) decorate(registry TypeRegistry, typeID string, generator TypeFactory) TypeFactory {
+	index := c.decorators.Load()
+	if replacements := registryReplacements.Load(); index == nil || index.registrySize != len(registry) || index.replacements != replacements {
+		index = newDecoratorIndex(registry, replacements)
+		c.decorators.Store(index)
+	}
+
+	decoratorTypeIDs := index.decorators[typeID]
+	if len(decoratorTypeIDs) == 0 {
+		return generator
+	}
+
+	result := &decoratedType{TypeFactory: generator}
+	for _, decoratorTypeID := range decoratorTypeIDs {
+		decorator, isDecorator := findEmbedded[*decoratorType](registry[decoratorTypeID])
+		if isDecorator && decorator.decoratedTypeID == typeID {
+			result.decorators = append(result.decorators, decorator)
+		}
+	}
+
+	if len(result.decorators) == 0 {
+		return generator
+	}
+
+	return result
+}
+
+// Arguments returns the arguments of the decorated type and all its decorators (without the inner references).
+func (t *decoratedType) Arguments() []interface{} {
+	args := t.TypeFactory.Arguments()
+	for _, decorator := range t.decorators {
+		for _, arg := range decorator.TypeFactory.Arguments() {
+			if s, isString := arg.(string); isString && IsTypeReference(s) && NewTypeID(s).ID == InnerTypeID {
+				continue
+			}
+
+			args = append(args, arg)
+		}
+	}
+
+	return args
+}
+
+func (t *decoratedType) Generate(resolver *ParameterResolver) (interface{}, error) {
+	instance, err := t.TypeFactory.Generate(resolver)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, decorator := range t.decorators {
+		innerResolver := &ParameterResolver{
+			Container:  resolver.Container,
+			resolution: resolver.resolution,
+			inner:      instance,
+			hasInner:   true,
+		}
+
+		instance, err = decorator.Generate(innerResolver)
+		if err != nil {
+			return nil, fmt.Errorf("could not apply decorator: %w", err)
+		}
+	}
+
+	return instance, nil
+}
+
+func (t *decoratedType) unwrap() TypeFactory {
+	return t.TypeFactory
+}
@@ -0,0 +1,199 @@
+package goldi_test
+
+import (
+	"fmt"
+	"math"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/tarokamikaze/goldi"
+	"github.com/tarokamikaze/goldi/validation"
+)
+
+// PrefixLogger is a test type that decorates another logger
+type PrefixLogger struct {
+	Prefix string
+	Inner  Logger
+}
+
+func NewPrefixLogger(inner Logger, prefix string) *PrefixLogger {
+	return &PrefixLogger{Prefix: prefix, Inner: inner}
+}
+
+func (l *PrefixLogger) Log(msg string) {
+	l.Inner.Log(l.Prefix + msg)
+}
+
+// chainOf returns the prefixes of all decorating PrefixLoggers from outermost to innermost
+func chainOf(logger interface{}) []string {
+	var prefixes []string
+	for {
+		decorator, isDecorator := logger.(*PrefixLogger)
+		if isDecorator == false {
+			return prefixes
+		}
+
+		prefixes = append(prefixes, decorator.Prefix)
+		logger = decorator.Inner
+	}
+}
+
+func ExampleNewDecoratorType() {
+	container := goldi.NewContainer(goldi.NewTypeRegistry(), map[string]interface{}{})
+
+	container.Register("logger", goldi.NewStructType(SimpleLogger{}))
+	container.Register("tracing_logger", goldi.NewDecoratorType(goldi.NewType(NewPrefixLogger, "@.inner", "[trace] "), "logger", 0))
+
+	logger := container.MustGet("logger").(*PrefixLogger)
+	fmt.Printf("%T decorates %T", logger, logger.Inner)
+	// Output:
+	// *goldi_test.PrefixLogger decorates *goldi_test.SimpleLogger
+}
+
+var _ = Describe("decoratorType", func() {
+	It("should implement the TypeFactory interface", func() {
+		var factory goldi.TypeFactory
+		factory = goldi.NewDecoratorType(goldi.NewType(NewPrefixLogger, "@.inner", "foo"), "logger", 0)
+		// if this compiles the test passes (next expectation only to make compiler happy)
+		Expect(factory).NotTo(BeNil())
+	})
+
+	Describe("NewDecoratorType()", func() {
+		It("should return an invalid type if the embedded type is nil", func() {
+			Expect(goldi.IsValid(goldi.NewDecoratorType(nil, "logger", 0))).To(BeFalse())
+		})
+
+		It("should return an invalid type if the decorated type ID is empty", func() {
+			t := goldi.NewDecoratorType(goldi.NewType(NewPrefixLogger, "@.inner", "foo"), " @", 0)
+			Expect(goldi.IsValid(t)).To(BeFalse())
+			Expect(t).To(MatchError("can not create a new DecoratorType with an empty decorated type ID"))
+		})
+	})
+
+	Describe("Arguments()", func() {
+		It("should return the arguments of the embedded type and the decorated type", func() {
+			t := goldi.NewDecoratorType(goldi.NewType(NewPrefixLogger, "@.inner", "%prefix%"), "@logger", 0)
+			Expect(t.Arguments()).To(Equal([]interface{}{"@.inner", "%prefix%", "@logger"}))
+		})
+	})
+
+	Describe("decorated types in the container", func() {
+		var (
+			registry  goldi.TypeRegistry
+			container *goldi.Container
+		)
+
+		decorator := func(prefix string, priority int) goldi.TypeFactory {
+			return goldi.NewDecoratorType(goldi.NewType(NewPrefixLogger, "@.inner", prefix), "logger", priority)
+		}
+
+		BeforeEach(func() {
+			registry = goldi.NewTypeRegistry()
+			container = goldi.NewContainer(registry, map[string]interface{}{})
+			registry.Register("logger", goldi.NewStructType(SimpleLogger{}))
+		})
+
+		It("should return the decorated type", func() {
+			registry.Register("logger.prefix", decorator("foo", 0))
+			Expect(chainOf(container.MustGet("logger"))).To(Equal([]string{"foo"}))
+		})
+
+		It("should stack the decorators by priority", func() {
+			registry.Register("logger.a", decorator("a", 0))
+			registry.Register("logger.b", decorator("b", 10))
+			registry.Register("logger.c", decorator("c", -10))
+			registry.Register("logger.d", decorator("d", 0))
+
+			// the decorator with the highest priority is applied first and thus is the innermost one
+			logger := container.MustGet("logger")
+			Expect(chainOf(logger)).To(Equal([]string{"c", "d", "a", "b"}))
+			Expect(logger.(*PrefixLogger).Inner.(*PrefixLogger).Inner.(*PrefixLogger).Inner.(*PrefixLogger).Inner).To(BeAssignableToTypeOf(&SimpleLogger{}))
+		})
+
+		It("should stack the decorators by priority if the priorities are far apart", func() {
+			registry.Register("logger.a", decorator("a", math.MinInt))
+			registry.Register("logger.b", decorator("b", math.MaxInt))
+			registry.Register("logger.c", decorator("c", 1))
+
+			Expect(chainOf(container.MustGet("logger"))).To(Equal([]string{"a", "c", "b"}))
+		})
+
+		It("should cache the decorated instance", func() {
+			registry.Register("logger.prefix", decorator("foo", 0))
+			Expect(container.MustGet("logger")).To(BeIdenticalTo(container.MustGet("logger")))
+			Expect(container.CollectCachedTypeIDs()).To(ConsistOf("logger"))
+		})
+
+		It("should inject the decorated type into other types", func() {
+			registry.Register("logger.prefix", decorator("foo", 0))
+			registry.Register("service", goldi.NewStructType(PrefixLogger{}, "bar", "@logger"))
+
+			Expect(chainOf(container.MustGet("service"))).To(Equal([]string{"bar", "foo"}))
+		})
+
+		It("should return the decorated type when the decorator is requested", func() {
+			registry.Register("logger.a", decorator("a", 0))
+			registry.Register("logger.b", decorator("b", 10))
+
+			Expect(container.MustGet("logger.b")).To(BeIdenticalTo(container.MustGet("logger")))
+		})
+
+		It("should apply decorators that are registered after the decorated type has been looked up", func() {
+			registry.Register("logger", goldi.NewScopedType(goldi.NewStructType(SimpleLogger{}), goldi.Prototype))
+			Expect(chainOf(container.MustGet("logger"))).To(BeEmpty())
+
+			container.Register("logger.a", decorator("a", 0))
+			Expect(chainOf(container.MustGet("logger"))).To(Equal([]string{"a"}))
+
+			container.Register("logger.a", goldi.NewStructType(SimpleLogger{}))
+			Expect(chainOf(container.MustGet("logger"))).To(BeEmpty())
+		})
+
+		It("should apply decorators that replace an existing type in the registry", func() {
+			registry.Register("logger", goldi.NewScopedType(goldi.NewStructType(SimpleLogger{}), goldi.Prototype))
+			registry.Register("other", goldi.NewStructType(SimpleLogger{}))
+			Expect(chainOf(container.MustGet("logger"))).To(BeEmpty())
+
+			registry.Register("other", decorator("a", 0))
+			Expect(chainOf(container.MustGet("logger"))).To(Equal([]string{"a"}))
+
+			registry.RegisterAll(map[string]goldi.TypeFactory{"other": goldi.NewStructType(SimpleLogger{})})
+			Expect(chainOf(container.MustGet("logger"))).To(BeEmpty())
+		})
+
+		It("should respect the scope of the decorated type", func() {
+			registry.Register("logger", goldi.NewScopedType(goldi.NewStructType(SimpleLogger{}), goldi.Prototype))
+			registry.Register("logger.prefix", decorator("foo", 0))
+
+			first, second := container.MustGet("logger"), container.MustGet("logger")
+			Expect(chainOf(first)).To(Equal([]string{"foo"}))
+			Expect(first).NotTo(BeIdenticalTo(second))
+			Expect(first.(*PrefixLogger).Inner).NotTo(BeIdenticalTo(second.(*PrefixLogger).Inner))
+		})
+
+		It("should return an error if a decorator can not be generated", func() {
+			registry.Register("logger.prefix", goldi.NewDecoratorType(goldi.NewType(NewPrefixLogger, "@.inner", "%unknown%"), "logger", 0))
+			registry.Register("logger.broken", goldi.NewDecoratorType(goldi.NewType(NewTypeForServiceInjection, "@.inner"), "logger", 0))
+
+			_, err := container.Get("logger")
+			Expect(err).To(MatchError(ContainSubstring(`goldi: error while generating type "logger": could not apply decorator`)))
+		})
+
+		It("should return an error if the inner type is referenced outside of a decorator", func() {
+			registry.RegisterType("service", NewPrefixLogger, "@.inner", "foo")
+
+			_, err := container.Get("service")
+			Expect(err).To(MatchError(`goldi: error while generating type "service": the type "@.inner" can only be referenced by decorators`))
+		})
+
+		It("should be accepted by the container validator", func() {
+			registry.Register("logger.prefix", decorator("foo", 0))
+			Expect(validation.NewContainerValidator().Validate(container)).To(Succeed())
+		})
+
+		It("should not be accepted by the container validator if the decorated type does not exist", func() {
+			registry.Register("foo.prefix", goldi.NewDecoratorType(goldi.NewType(NewPrefixLogger, "@.inner", "foo"), "foo", 0))
+			Expect(validation.NewContainerValidator().Validate(container)).To(MatchError(`container validation failed: type "foo.prefix" references unknown type "foo"`))
+		})
+	})
+})
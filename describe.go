@@ -0,0 +1,203 @@
+package goldi
+
+import (
+	"reflect"
+	"runtime"
+	"strings"
+)
+
+// A FactoryKind names the kind of TypeFactory that generates a type (see DescribeType).
+type FactoryKind string
+
+// The kinds of all type factories of goldi. Custom TypeFactory implementations are described as KindUnknown.
+const (
+	KindType          FactoryKind = "type"           // NewType
+	KindAutowired     FactoryKind = "autowired"      // NewAutowiredType
+	KindStruct        FactoryKind = "struct"         // NewStructType
+	KindFunc          FactoryKind = "func"           // NewFuncType
+	KindInstance      FactoryKind = "instance"       // NewInstanceType and NewOwnedInstanceType
+	KindAlias         FactoryKind = "alias"          // NewAliasType
+	KindProxy         FactoryKind = "proxy"          // NewProxyType
+	KindFuncReference FactoryKind = "func_reference" // NewFuncReferenceType
+	KindInvalid       FactoryKind = "invalid"        // a TypeFactory that could not be created (see IsValid)
+	KindUnknown       FactoryKind = "unknown"
+)
+
+// A DependencyKind describes how a type depends on another type.
+type DependencyKind string
+
+// The kinds of dependencies between types.
+const (
+	ReferenceDependency     DependencyKind = "reference"      // "@logger"
+	OptionalDependency      DependencyKind = "optional"       // "@?logger"
+	FuncReferenceDependency DependencyKind = "func_reference" // "@logger::Log" or NewFuncReferenceType
+	TaggedDependency        DependencyKind = "tagged"         // "!tagged http.middleware"
+	AliasDependency         DependencyKind = "alias"          // NewAliasType
+	ProxyDependency         DependencyKind = "proxy"          // NewProxyType
+	ConfiguratorDependency  DependencyKind = "configurator"   // NewConfiguredType
+	DecoratorDependency     DependencyKind = "decorates"      // NewDecoratorType
+	AutowiredDependency     DependencyKind = "autowired"      // an argument of NewAutowiredType that is resolved by its type
+)
+
+// A Dependency describes a type (or a group of tagged types) another type depends on.
+type Dependency struct {
+	Kind DependencyKind
+
+	// TypeID is the ID of the type that is depended on. It is empty for tagged dependencies.
+	TypeID string
+
+	// Method is the name of the method that is used for func references, proxies and configurators.
+	Method string
+
+	// Tag is the name of the tag of a tagged dependency.
+	Tag string
+
+	// Type is the argument type of an autowired dependency. Its TypeID is empty because the type that is injected
+	// depends on the other types of the registry (see TypeRegistry.AutowireCandidates).
+	Type reflect.Type
+}
+
+// A TypeDescription describes how a TypeFactory generates its type and which other types it depends on.
+type TypeDescription struct {
+	Kind FactoryKind
+
+	// Package is the import path of the package that declares the factory function or the generated type.
+	Package string
+
+	// Function is the name of the factory function, the generated type or the referenced method.
+	Function string
+
+	Scope        Scope
+	Eager        bool
+	Tags         []Tag
+	Dependencies []Dependency
+
+	// Err is the reason why a type of KindInvalid could not be created.
+	Err error
+}
+
+// DescribeType returns a description of the given TypeFactory which can be used for documentation and visualization
+// (see the goldi/graph package). The dependencies are taken from the type references and tagged references of the
+// arguments (including the goldi tags of struct types), the autowired arguments and from the type factories that
+// are wrapped around the actual factory (e.g. configurators).
+func DescribeType(t TypeFactory) TypeDescription {
+	description := TypeDescription{
+		Scope: ScopeOf(t),
+		Eager: IsEager(t),
+		Tags:  TagsOf(t),
+	}
+
+	var wrapperDependencies []Dependency
+	for {
+		switch factory := t.(type) {
+		case *configuredType:
+			wrapperDependencies = append(wrapperDependencies, Dependency{Kind: ConfiguratorDependency, TypeID: factory.ConfiguratorTypeID, Method: factory.MethodName})
+		case *decoratorType:
+			wrapperDependencies = append(wrapperDependencies, Dependency{Kind: DecoratorDependency, TypeID: factory.decoratedTypeID})
+		}
+
+		wrapped, isWrapped := t.(wrappedTypeFactory)
+		if isWrapped == false {
+			break
+		}
+
+		t = wrapped.unwrap()
+	}
+
+	switch factory := t.(type) {
+	case *typeFactory:
+		description.Kind = KindType
+		description.Package, description.Function = funcName(factory.factory)
+		description.Dependencies = argumentDependencies(factory.Arguments())
+	case *autowiredType:
+		description.Kind = KindAutowired
+		description.Package, description.Function = funcName(reflect.ValueOf(factory.factory))
+		description.Dependencies = argumentDependencies(factory.Arguments())
+		for _, argumentType := range factory.autowiredArgumentTypes() {
+			description.Dependencies = append(description.Dependencies, Dependency{Kind: AutowiredDependency, Type: argumentType})
+		}
+	case *structType:
+		description.Kind = KindStruct
+		description.Package, description.Function = factory.structType.PkgPath(), factory.structType.Name()
+		description.Dependencies = argumentDependencies(factory.Arguments())
+	case *funcType:
+		description.Kind = KindFunc
+		description.Package, description.Function = funcName(reflect.ValueOf(factory.function))
+	case *instanceType:
+		description.Kind = KindInstance
+		instanceType := reflect.TypeOf(factory.Instance)
+		for instanceType.Kind() == reflect.Ptr {
+			instanceType = instanceType.Elem()
+		}
+		description.Package, description.Function = instanceType.PkgPath(), instanceType.Name()
+	case *aliasType:
+		description.Kind = KindAlias
+		typeID := NewTypeID(factory.typeID)
+		description.Function = typeID.FuncReferenceMethod
+		description.Dependencies = []Dependency{{Kind: AliasDependency, TypeID: typeID.ID, Method: typeID.FuncReferenceMethod}}
+	case *proxyType:
+		description.Kind = KindProxy
+		description.Function = factory.typeID.FuncReferenceMethod
+		description.Dependencies = append(
+			[]Dependency{{Kind: ProxyDependency, TypeID: factory.typeID.ID, Method: factory.typeID.FuncReferenceMethod}},
+			argumentDependencies(factory.args)...,
+		)
+	case *funcReferenceType:
+		description.Kind = KindFuncReference
+		description.Function = factory.typeID.FuncReferenceMethod
+		description.Dependencies = []Dependency{{Kind: FuncReferenceDependency, TypeID: factory.typeID.ID, Method: factory.typeID.FuncReferenceMethod}}
+	case *invalidType:
+		description.Kind = KindInvalid
+		description.Err = factory.error
+	default:
+		description.Kind = KindUnknown
+		if t != nil {
+			description.Dependencies = argumentDependencies(t.Arguments())
+		}
+	}
+
+	description.Dependencies = append(description.Dependencies, wrapperDependencies...)
+	return description
+}
+
+// argumentDependencies returns the dependencies of all type references and tagged references in the given arguments.
+func argumentDependencies(arguments []interface{}) []Dependency {
+	var dependencies []Dependency
+	for _, argument := range arguments {
+		s, isString := argument.(string)
+		switch {
+		case isString == false:
+		case IsTypeReference(s):
+			typeID := NewTypeID(s)
+			switch {
+			case typeID.ID == InnerTypeID:
+			case typeID.IsOptional:
+				dependencies = append(dependencies, Dependency{Kind: OptionalDependency, TypeID: typeID.ID, Method: typeID.FuncReferenceMethod})
+			case typeID.IsFuncReference:
+				dependencies = append(dependencies, Dependency{Kind: FuncReferenceDependency, TypeID: typeID.ID, Method: typeID.FuncReferenceMethod})
+			default:
+				dependencies = append(dependencies, Dependency{Kind: ReferenceDependency, TypeID: typeID.ID})
+			}
+		case IsTaggedReference(s):
+			dependencies = append(dependencies, Dependency{Kind: TaggedDependency, Tag: strings.TrimSpace(s[len(taggedReferencePrefix):])})
+		}
+	}
+
+	return dependencies
+}
+
+// funcName returns the package path and the name of the given function (e.g. "github.com/foo/bar" and "NewBaz").
+func funcName(function reflect.Value) (packagePath, name string) {
+	if function.Kind() != reflect.Func || function.IsNil() {
+		return "", ""
+	}
+
+	fullName := strings.TrimSuffix(runtime.FuncForPC(function.Pointer()).Name(), "-fm")
+	lastSlash := strings.LastIndex(fullName, "/")
+	dot := strings.Index(fullName[lastSlash+1:], ".")
+	if dot < 0 {
+		return "", fullName
+	}
+
+	return fullName[:lastSlash+1+dot], fullName[lastSlash+2+dot:]
+}
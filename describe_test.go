@@ -0,0 +1,121 @@
+package goldi_test
+
+import (
+	"reflect"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/tarokamikaze/goldi"
+)
+
+var _ = Describe("DescribeType", func() {
+	const testPackage = "github.com/tarokamikaze/goldi_test"
+
+	It("should describe types with factory functions", func() {
+		description := goldi.DescribeType(goldi.NewType(NewTypeForServiceInjectionWithArgs, "@mock", "%name%", "@?location", false))
+		Expect(description.Kind).To(Equal(goldi.KindType))
+		Expect(description.Package).To(Equal(testPackage))
+		Expect(description.Function).To(Equal("NewTypeForServiceInjectionWithArgs"))
+		Expect(description.Scope).To(Equal(goldi.Singleton))
+		Expect(description.Dependencies).To(Equal([]goldi.Dependency{
+			{Kind: goldi.ReferenceDependency, TypeID: "mock"},
+			{Kind: goldi.OptionalDependency, TypeID: "location"},
+		}))
+	})
+
+	It("should describe autowired types", func() {
+		description := goldi.DescribeType(goldi.NewAutowiredType(NewAutowiredService, "@logger"))
+		Expect(description.Kind).To(Equal(goldi.KindAutowired))
+		Expect(description.Function).To(Equal("NewAutowiredService"))
+		Expect(description.Dependencies).To(Equal([]goldi.Dependency{
+			{Kind: goldi.ReferenceDependency, TypeID: "logger"},
+			{Kind: goldi.AutowiredDependency, Type: reflect.TypeOf(&MockType{})},
+			{Kind: goldi.AutowiredDependency, Type: reflect.TypeOf("")},
+		}))
+
+		description = goldi.DescribeType(goldi.NewAutowiredType(NewAutowiredServiceWithContext))
+		Expect(description.Dependencies).To(Equal([]goldi.Dependency{{Kind: goldi.AutowiredDependency, Type: reflect.TypeOf((*Logger)(nil)).Elem()}}))
+	})
+
+	It("should describe struct types", func() {
+		description := goldi.DescribeType(goldi.NewStructType(&Closable{}, "client", "@log"))
+		Expect(description.Kind).To(Equal(goldi.KindStruct))
+		Expect(description.Package).To(Equal(testPackage))
+		Expect(description.Function).To(Equal("Closable"))
+		Expect(description.Dependencies).To(Equal([]goldi.Dependency{{Kind: goldi.ReferenceDependency, TypeID: "log"}}))
+
+		description = goldi.DescribeType(goldi.NewStructType(&TaggedService{}))
+		Expect(description.Dependencies).To(Equal([]goldi.Dependency{
+			{Kind: goldi.ReferenceDependency, TypeID: "mock"},
+			{Kind: goldi.OptionalDependency, TypeID: "metrics"},
+		}))
+	})
+
+	It("should describe func and instance types", func() {
+		description := goldi.DescribeType(goldi.NewFuncType(NewFoo))
+		Expect(description.Kind).To(Equal(goldi.KindFunc))
+		Expect(description.Function).To(Equal("NewFoo"))
+
+		description = goldi.DescribeType(goldi.NewInstanceType(&Foo{}))
+		Expect(description.Kind).To(Equal(goldi.KindInstance))
+		Expect(description.Package).To(Equal(testPackage))
+		Expect(description.Function).To(Equal("Foo"))
+	})
+
+	It("should describe aliases, proxies and func references", func() {
+		description := goldi.DescribeType(goldi.NewAliasType("logger"))
+		Expect(description.Kind).To(Equal(goldi.KindAlias))
+		Expect(description.Dependencies).To(Equal([]goldi.Dependency{{Kind: goldi.AliasDependency, TypeID: "logger"}}))
+
+		description = goldi.DescribeType(goldi.NewProxyType("logger_provider", "GetLogger", "%name%", "@mock"))
+		Expect(description.Kind).To(Equal(goldi.KindProxy))
+		Expect(description.Function).To(Equal("GetLogger"))
+		Expect(description.Dependencies).To(Equal([]goldi.Dependency{
+			{Kind: goldi.ProxyDependency, TypeID: "logger_provider", Method: "GetLogger"},
+			{Kind: goldi.ReferenceDependency, TypeID: "mock"},
+		}))
+
+		description = goldi.DescribeType(goldi.NewFuncReferenceType("foo", "ReturnString"))
+		Expect(description.Kind).To(Equal(goldi.KindFuncReference))
+		Expect(description.Dependencies).To(Equal([]goldi.Dependency{{Kind: goldi.FuncReferenceDependency, TypeID: "foo", Method: "ReturnString"}}))
+	})
+
+	It("should describe func references and tagged references in the arguments", func() {
+		description := goldi.DescribeType(goldi.NewType(NewMockTypeFromStringFunc, "foo", "@foo::ReturnString"))
+		Expect(description.Dependencies).To(Equal([]goldi.Dependency{{Kind: goldi.FuncReferenceDependency, TypeID: "foo", Method: "ReturnString"}}))
+
+		description = goldi.DescribeType(goldi.NewType(NewRouter, "!tagged http.middleware"))
+		Expect(description.Dependencies).To(Equal([]goldi.Dependency{{Kind: goldi.TaggedDependency, Tag: "http.middleware"}}))
+	})
+
+	It("should describe the wrapped type factories", func() {
+		generator := goldi.NewTaggedType(
+			goldi.NewScopedType(
+				goldi.NewConfiguredType(goldi.NewType(NewFoo), "configurator", "Configure"),
+				goldi.Prototype,
+			),
+			goldi.Tag{Name: "foos"},
+		)
+
+		description := goldi.DescribeType(generator)
+		Expect(description.Kind).To(Equal(goldi.KindType))
+		Expect(description.Function).To(Equal("NewFoo"))
+		Expect(description.Scope).To(Equal(goldi.Prototype))
+		Expect(description.Tags).To(Equal([]goldi.Tag{{Name: "foos"}}))
+		Expect(description.Dependencies).To(Equal([]goldi.Dependency{
+			{Kind: goldi.ConfiguratorDependency, TypeID: "configurator", Method: "Configure"},
+		}))
+	})
+
+	It("should describe decorators without the reference to the inner type", func() {
+		description := goldi.DescribeType(goldi.NewDecoratorType(goldi.NewType(NewPrefixLogger, "@.inner", "%prefix%"), "logger", 0))
+		Expect(description.Function).To(Equal("NewPrefixLogger"))
+		Expect(description.Dependencies).To(Equal([]goldi.Dependency{{Kind: goldi.DecoratorDependency, TypeID: "logger"}}))
+	})
+
+	It("should describe invalid types", func() {
+		description := goldi.DescribeType(goldi.NewType(42))
+		Expect(description.Kind).To(Equal(goldi.KindInvalid))
+		Expect(description.Err).To(HaveOccurred())
+	})
+})
@@ -0,0 +1,37 @@
+package goldi
+
+import "fmt"
+
+type eagerType struct {
+	TypeFactory
+}
+
+// NewEagerType creates a new TypeFactory that decorates a given TypeFactory and marks it as eager.
+// Eager types are generated when the container is started (see Container.Start) instead of the first time they
+// are requested. Use this for types which nothing else depends on like background workers or servers.
+//
+// NewEagerType will return an invalid type when embeddedType is nil.
+//
+// Goldigen yaml syntax example:
+//
+//	http_server:
+//	    package: github.com/fgrosse/foobar
+//	    factory: NewServer
+//	    eager:   true
+func NewEagerType(embeddedType TypeFactory) TypeFactory {
+	if embeddedType == nil {
+		return newInvalidType(fmt.Errorf("refusing to create a new EagerType with nil as embedded type"))
+	}
+
+	return &eagerType{embeddedType}
+}
+
+func (t *eagerType) unwrap() TypeFactory {
+	return t.TypeFactory
+}
+
+// IsEager returns whether the given TypeFactory has been marked as eager using NewEagerType.
+func IsEager(t TypeFactory) bool {
+	_, isEager := findEmbedded[*eagerType](t)
+	return isEager
+}
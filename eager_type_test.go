@@ -0,0 +1,33 @@
+package goldi_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/tarokamikaze/goldi"
+)
+
+var _ = Describe("eagerType", func() {
+	It("should implement the TypeFactory interface", func() {
+		var factory goldi.TypeFactory
+		factory = goldi.NewEagerType(goldi.NewType(NewMockType))
+		// if this compiles the test passes (next expectation only to make compiler happy)
+		Expect(factory).NotTo(BeNil())
+	})
+
+	It("should return an invalid type if the embedded type is nil", func() {
+		Expect(goldi.IsValid(goldi.NewEagerType(nil))).To(BeFalse())
+	})
+
+	It("should return the arguments of the embedded type", func() {
+		t := goldi.NewEagerType(goldi.NewType(NewMockTypeWithArgs, "%foo%", "@bar"))
+		Expect(t.Arguments()).To(Equal([]interface{}{"%foo%", "@bar"}))
+	})
+
+	Describe("IsEager()", func() {
+		It("should return whether a type has been marked as eager", func() {
+			Expect(goldi.IsEager(goldi.NewType(NewMockType))).To(BeFalse())
+			Expect(goldi.IsEager(goldi.NewEagerType(goldi.NewType(NewMockType)))).To(BeTrue())
+			Expect(goldi.IsEager(goldi.NewScopedType(goldi.NewEagerType(goldi.NewType(NewMockType)), goldi.Scoped))).To(BeTrue())
+		})
+	})
+})
@@ -1,6 +1,11 @@
 package goldi
 
-import "fmt"
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+)
 
 // A TypeReferenceError occurs if you tried to inject a type that does not match the function declaration of the corresponding method.
 type TypeReferenceError struct {
@@ -15,6 +20,58 @@ type UnknownTypeReferenceError struct {
 	TypeID string
 }
 
+// A CircularDependencyError occurs if a type depends on itself, either directly or through other types.
+// The Path contains the IDs of all types of the cycle in the order in which they have been resolved.
+// The first and the last element of the Path are always the same type ID (e.g. a -> b -> c -> a).
+type CircularDependencyError struct {
+	Path []string
+}
+
+func (e CircularDependencyError) Error() string {
+	return fmt.Sprintf("goldi: circular dependency detected: %s", strings.Join(e.Path, " -> "))
+}
+
+// A ParameterError occurs if the value of a parameter can not be converted into the type of the argument it should
+// be injected into. The TypeID and the zero based ArgumentIndex are only known if the parameter has been resolved
+// while the container generated a type (otherwise they are empty and -1).
+type ParameterError struct {
+	Parameter     string
+	Value         interface{}
+	ExpectedType  reflect.Type
+	TypeID        string
+	ArgumentIndex int
+	Err           error
+}
+
+func (e *ParameterError) Error() string {
+	msg := fmt.Sprintf("goldi: can not use parameter %q (value %#v) as %v", e.Parameter, e.Value, e.ExpectedType)
+	if e.ArgumentIndex >= 0 {
+		msg += fmt.Sprintf(" for argument %d", e.ArgumentIndex+1)
+	}
+
+	if e.TypeID != "" {
+		msg += fmt.Sprintf(" of type %q", e.TypeID)
+	}
+
+	return msg + ": " + e.Err.Error()
+}
+
+// Unwrap returns the reason why the parameter could not be converted.
+func (e *ParameterError) Unwrap() error {
+	return e.Err
+}
+
+// withArgumentIndex sets the argument index of a ParameterError that has been returned while resolving the i-th
+// argument of a type. Errors of types that have been generated for a type reference are left untouched.
+func withArgumentIndex(err error, i int) error {
+	var parameterErr *ParameterError
+	if errors.As(err, &parameterErr) && parameterErr.TypeID == "" && parameterErr.ArgumentIndex < 0 {
+		parameterErr.ArgumentIndex = i
+	}
+
+	return err
+}
+
 // newTypeReferenceError creates a new TypeReferenceError
 func newTypeReferenceError(typeID string, typeInstance interface{}, message string, printfParameters ...interface{}) TypeReferenceError {
 	return TypeReferenceError{
@@ -31,3 +88,14 @@ func newUnknownTypeReferenceError(typeID, message string, printfParameters ...in
 		TypeID: typeID,
 	}
 }
+
+// newCircularDependencyError creates a new CircularDependencyError for a cycle that consists of the given types
+// and is closed by requesting the type identified by key again.
+func newCircularDependencyError(cycle []flightKey, key flightKey) CircularDependencyError {
+	path := make([]string, 0, len(cycle)+1)
+	for _, k := range cycle {
+		path = append(path, k.typeID)
+	}
+
+	return CircularDependencyError{Path: append(path, key.typeID)}
+}
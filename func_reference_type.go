@@ -28,9 +28,9 @@ func (t *funcReferenceType) Arguments() []interface{} {
 }
 
 func (t *funcReferenceType) Generate(resolver *ParameterResolver) (interface{}, error) {
-	referencedType, err := resolver.Container.Get(t.typeID.ID)
+	referencedType, err := resolver.Get(t.typeID.ID)
 	if err != nil {
-		return nil, fmt.Errorf("could not generate func reference type %s : %s", t.typeID, err)
+		return nil, fmt.Errorf("could not generate func reference type %s : %w", t.typeID, err)
 	}
 
 	v := reflect.ValueOf(referencedType)
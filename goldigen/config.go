@@ -32,6 +32,12 @@ func NewConfig(completePackage, functionName, inputPath, outputPath string) Conf
 	return Config{completePackage, functionName, inputPath, outputPath}
 }
 
+// ParametersFunctionName returns the name of the generated function that registers the default parameters.
+// It is derived from the function name by replacing a "Types" suffix so "RegisterTypes" becomes "RegisterParameters".
+func (c Config) ParametersFunctionName() string {
+	return strings.TrimSuffix(c.FunctionName, "Types") + "Parameters"
+}
+
 // PackageName returns the name of the configured package.
 func (c Config) PackageName() string {
 	packageParts := strings.Split(c.Package, "/")
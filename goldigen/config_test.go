@@ -18,6 +18,13 @@ var _ = Describe("Config", func() {
 		})
 	})
 
+	Describe("ParametersFunctionName", func() {
+		It("should derive the name from the type registration function", func() {
+			Expect(main.NewConfig("foo", "", "", "").ParametersFunctionName()).To(Equal("RegisterParameters"))
+			Expect(main.NewConfig("foo", "RegisterServices", "", "").ParametersFunctionName()).To(Equal("RegisterServicesParameters"))
+		})
+	})
+
 	Describe("PackageName", func() {
 		It("should only return the package name", func() {
 			config := main.NewConfig("github.com/fgrosse/servo", "", "", "")
@@ -49,6 +49,7 @@ func (g *Generator) Generate(input io.Reader, output io.Writer) error {
 	g.generateImports(conf, output)
 	g.generateGoldiGenComment(output)
 	g.generateTypeRegistrationFunction(conf, output)
+	g.generateParameterRegistrationFunction(conf, output)
 
 	// TODO: once done check if the output is valid go code
 	return nil
@@ -140,6 +141,34 @@ func captureStrings(config *TypesConfiguration) {
 
 		config.Types[id] = t
 	}
+
+	if config.Parameters != nil {
+		parameters := make(map[string]interface{}, len(config.Parameters))
+		for name, value := range config.Parameters {
+			parameters[unescape(name)] = unescapeParameter(value, unescape)
+		}
+		config.Parameters = parameters
+	}
+}
+
+// unescapeParameter applies unescape to all strings of a (possibly nested) parameter value.
+func unescapeParameter(value interface{}, unescape func(string) string) interface{} {
+	switch v := value.(type) {
+	case string:
+		return unescape(v)
+	case []interface{}:
+		for i, element := range v {
+			v[i] = unescapeParameter(element, unescape)
+		}
+	case map[interface{}]interface{}:
+		unescaped := make(map[interface{}]interface{}, len(v))
+		for key, element := range v {
+			unescaped[unescapeParameter(key, unescape)] = unescapeParameter(element, unescape)
+		}
+		return unescaped
+	}
+
+	return value
 }
 
 func (g *Generator) generateGoGenerateLine(output io.Writer) {
@@ -206,6 +235,38 @@ func (g *Generator) generateTypeRegistrationFunction(conf *TypesConfiguration, o
 	fmt.Fprint(output, "}\n")
 }
 
+// generateParameterRegistrationFunction generates a function that adds the values of the parameters section to
+// a parameter config. Parameters that are already set by the caller are not overwritten and nested maps are merged
+// (see goldi.SetParameterDefaults).
+func (g *Generator) generateParameterRegistrationFunction(conf *TypesConfiguration, output io.Writer) {
+	if len(conf.Parameters) == 0 {
+		return
+	}
+
+	names := make([]string, 0, len(conf.Parameters))
+	maxNameLength := 0
+	for name := range conf.Parameters {
+		names = append(names, name)
+		if len(name) > maxNameLength {
+			maxNameLength = len(name)
+		}
+	}
+	sort.Strings(names)
+
+	functionName := g.Config.ParametersFunctionName()
+	fmt.Fprintf(output, "\n// %s adds all parameters that have been defined in the file %q to the given config.\n", functionName, g.Config.InputName())
+	fmt.Fprintf(output, "// Parameters that are already set in the config are not overwritten so the defaults can be overridden.\n")
+	fmt.Fprintf(output, "// Nested maps are merged so the config may only override some of their values.\n")
+	fmt.Fprintf(output, "func %s(config map[string]interface{}) {\n", functionName)
+	fmt.Fprint(output, "\tgoldi.SetParameterDefaults(config, map[string]interface{}{\n")
+	for _, name := range names {
+		spaces := strings.Repeat(" ", maxNameLength-len(name))
+		fmt.Fprintf(output, "\t\t%q: %s%s,\n", name, spaces, ParameterCode(conf.Parameters[name]))
+	}
+	fmt.Fprint(output, "\t})\n")
+	fmt.Fprint(output, "}\n")
+}
+
 func (g *Generator) logVerbose(message string, args ...interface{}) {
 	if g.Debug {
 		fmt.Fprintf(g.Logger, message+"\n", args...)
@@ -179,6 +179,50 @@ var _ = Describe("Generator", func() {
 				}
 			`))
 		})
+
+		It("should generate a function that registers the parameters as typed defaults", func() {
+			exampleYaml = `
+			parameters:
+				graphigo.base_url: https://example.com/graphigo:8443
+				graphigo.timeout: 30
+				debug: false
+				admin: admin@example.com
+				hosts: [ a.example.com, b.example.com ]
+				database:
+					host: localhost
+					port: 5432
+
+			types:
+				graphigo.client:
+					package: github.com/fgrosse/graphigo
+					type:    Graphigo
+					factory: NewClient
+		`
+			Expect(gen.Generate(strings.NewReader(exampleYaml), output)).To(Succeed())
+			Expect(output).To(ContainCode(`
+				func RegisterParameters(config map[string]interface{}) {
+					goldi.SetParameterDefaults(config, map[string]interface{}{
+						"admin":             "admin@example.com",
+						"database":          map[string]interface{}{"host": "localhost", "port": 5432},
+						"debug":             false,
+						"graphigo.base_url": "https://example.com/graphigo:8443",
+						"graphigo.timeout":  30,
+						"hosts":             []interface{}{"a.example.com", "b.example.com"},
+					})
+				}
+			`))
+		})
+	})
+
+	It("should not generate a parameter function if no parameters have been defined", func() {
+		input := `
+			types:
+				foo:
+					package: github.com/fgrosse/some/thing
+					type:    Foo
+		`
+		Expect(gen.Generate(strings.NewReader(input), output)).To(Succeed())
+		Expect(output.String()).NotTo(ContainSubstring("RegisterParameters"))
 	})
 
 	It("should validate the input", func() {
@@ -240,6 +284,91 @@ var _ = Describe("Generator", func() {
 		`))
 	})
 
+	It("should allow specifying the scope of types", func() {
+		input := `
+			types:
+				test:
+					package: foo/bar
+					factory: NewFoo
+					scope:   prototype
+		`
+		Expect(gen.Generate(strings.NewReader(input), output)).To(Succeed())
+		Expect(output).To(BeValidGoCode())
+		Expect(output).To(ContainCode(`
+			func RegisterTypes(types goldi.TypeRegistry) {
+				types.Register("test", goldi.NewScopedType(goldi.NewType(bar.NewFoo), goldi.Prototype))
+			}
+		`))
+	})
+
+	It("should allow tagging types", func() {
+		input := `
+			types:
+				test:
+					package: foo/bar
+					factory: NewFoo
+					tags:
+						- foo.bar
+						- { name: health_check, priority: 10, path: /health }
+		`
+		Expect(gen.Generate(strings.NewReader(input), output)).To(Succeed())
+		Expect(output).To(BeValidGoCode())
+		Expect(output).To(ContainCode(`
+			func RegisterTypes(types goldi.TypeRegistry) {
+				types.Register("test", goldi.NewTaggedType(goldi.NewType(bar.NewFoo), goldi.Tag{Name: "foo.bar"}, goldi.Tag{Name: "health_check", Priority: 10, Attributes: map[string]interface{}{"path": "/health"}}))
+			}
+		`))
+	})
+
+	It("should allow decorating types", func() {
+		input := `
+			types:
+				tracing_logger:
+					package: foo/bar
+					factory: NewTracingLogger
+					args:    [ "@.inner" ]
+					decorates: logger
+					decoration_priority: -5
+		`
+		Expect(gen.Generate(strings.NewReader(input), output)).To(Succeed())
+		Expect(output).To(BeValidGoCode())
+		Expect(output).To(ContainCode(`
+			func RegisterTypes(types goldi.TypeRegistry) {
+				types.Register("tracing_logger", goldi.NewDecoratorType(goldi.NewType(bar.NewTracingLogger, "@.inner"), "logger", -5))
+			}
+		`))
+	})
+
+	It("should allow autowiring types", func() {
+		input := `
+			types:
+				test:
+					package:  foo/bar
+					factory:  NewFoo
+					autowire: true
+					args:     [ ~, "%name%" ]
+		`
+		Expect(gen.Generate(strings.NewReader(input), output)).To(Succeed())
+		Expect(output).To(BeValidGoCode())
+		Expect(output).To(ContainCode(`
+			func RegisterTypes(types goldi.TypeRegistry) {
+				types.Register("test", goldi.NewAutowiredType(bar.NewFoo, nil, "%name%"))
+			}
+		`))
+	})
+
+	It("should return an error if a tag can not be parsed", func() {
+		input := `
+			types:
+				test:
+					package: foo/bar
+					factory: NewFoo
+					tags:
+						- { name: health_check, priority: high }
+		`
+		Expect(gen.Generate(strings.NewReader(input), output)).To(MatchError(ContainSubstring(`tag key "priority" has invalid value high`)))
+	})
+
 	It("should log message in debug mode", func() {
 		logger := new(bytes.Buffer)
 		gen.Debug = true
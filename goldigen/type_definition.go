@@ -2,6 +2,7 @@ package main
 
 import (
 	"fmt"
+	"maps"
 	"regexp"
 	"slices"
 	"strings"
@@ -16,10 +17,21 @@ type TypeDefinition struct {
 	FactoryMethod string   `yaml:"factory"`
 	AliasForType  string   `yaml:"alias"`
 	Configurator  []string `yaml:"configurator"`
+	Scope         string   `yaml:"scope,omitempty"`
+	Eager         bool     `yaml:"eager,omitempty"`
+	Autowire      bool     `yaml:"autowire,omitempty"`
+
+	Tags []TagDefinition `yaml:"tags,omitempty"`
+
+	Decorates          string `yaml:"decorates,omitempty"`
+	DecorationPriority int    `yaml:"decoration_priority,omitempty"`
 
 	RawArguments      []interface{} `yaml:"arguments,omitempty"`
 	RawArgumentsShort []interface{} `yaml:"args,omitempty"`
 
+	// Fields maps the field names of a struct type to their arguments
+	Fields map[string]interface{} `yaml:"fields,omitempty"`
+
 	// ForcePackageName can be used in case the full package does not correspond to the actual package name
 	ForcePackageName string `yaml:"package-name,omitempty"`
 }
@@ -72,6 +84,36 @@ func (t *TypeDefinition) Validate(typeID string) error {
 		}
 	}
 
+	if t.Scope != "" {
+		if _, isKnownScope := scopeConstants[t.Scope]; isKnownScope == false {
+			return fmt.Errorf("type definition of %q has unknown scope %q", typeID, t.Scope)
+		}
+	}
+
+	if len(t.Fields) > 0 {
+		if t.TypeName == "" || t.FactoryMethod != "" || t.FuncName != "" {
+			return fmt.Errorf("type definition of %q has fields but is no struct type", typeID)
+		}
+
+		if len(t.RawArguments) > 0 || len(t.RawArgumentsShort) > 0 {
+			return fmt.Errorf("type definition of %q can not have both fields and arguments. Please decide for one of them", typeID)
+		}
+	}
+
+	if t.Autowire && (t.FactoryMethod == "" || t.FactoryMethod[0] == '@') {
+		return fmt.Errorf("type definition of %q can only be autowired if it uses a factory function", typeID)
+	}
+
+	if t.DecorationPriority != 0 && strings.TrimSpace(t.Decorates) == "" {
+		return fmt.Errorf("type definition of %q has a decoration priority but does not decorate any type", typeID)
+	}
+
+	for _, tag := range t.Tags {
+		if err := tag.Validate(typeID); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
@@ -102,6 +144,65 @@ func (t *TypeDefinition) requireField(fieldName, value, typeID string) error {
 	return nil
 }
 
+// A TagDefinition holds the name, priority and attributes of a tag of a type.
+// In the yaml file a tag can either be given as plain name or as map with a "name" and an optional "priority" key.
+// All other keys of the map are used as attributes of the tag.
+type TagDefinition struct {
+	Name       string
+	Priority   int
+	Attributes map[string]interface{}
+}
+
+// UnmarshalYAML implements the yaml.Unmarshaler interface
+func (t *TagDefinition) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	if err := unmarshal(&t.Name); err == nil {
+		return nil
+	}
+
+	var raw map[string]interface{}
+	if err := unmarshal(&raw); err != nil {
+		return err
+	}
+
+	for key, value := range raw {
+		var isValid bool
+		switch key {
+		case "name":
+			t.Name, isValid = value.(string)
+		case "priority":
+			t.Priority, isValid = value.(int)
+		default:
+			if t.Attributes == nil {
+				t.Attributes = map[string]interface{}{}
+			}
+			t.Attributes[key], isValid = value, true
+		}
+
+		if isValid == false {
+			return fmt.Errorf("tag key %q has invalid value %v", key, value)
+		}
+	}
+
+	return nil
+}
+
+// Validate checks if this tag has a name and only contains scalar attributes
+func (t *TagDefinition) Validate(typeID string) error {
+	if strings.TrimSpace(t.Name) == "" {
+		return fmt.Errorf("type definition of %q contains a tag without name", typeID)
+	}
+
+	for key, value := range t.Attributes {
+		switch value.(type) {
+		case string, int, float64, bool:
+		default:
+			return fmt.Errorf("tag %q of type %q has attribute %q with unsupported value %v", t.Name, typeID, key, value)
+		}
+	}
+
+	return nil
+}
+
 var versionSuffix = regexp.MustCompile(`\.v\d+$`)
 
 func (t *TypeDefinition) PackageName() string {
@@ -118,12 +219,27 @@ func (t *TypeDefinition) Arguments() []string {
 	rawArgs := slices.Concat(t.RawArguments, t.RawArgumentsShort)
 	arguments := make([]string, len(rawArgs))
 	for i, arg := range rawArgs {
-		switch a := arg.(type) {
-		case string:
-			arguments[i] = fmt.Sprintf(`"%s"`, a)
-		default:
-			arguments[i] = fmt.Sprintf("%v", a)
-		}
+		arguments[i] = argumentCode(arg)
 	}
 	return arguments
 }
+
+// FieldArguments returns the names of all fields in alphabetical order and the code of their arguments
+func (t *TypeDefinition) FieldArguments() (names []string, arguments []string) {
+	for _, name := range slices.Sorted(maps.Keys(t.Fields)) {
+		names = append(names, name)
+		arguments = append(arguments, argumentCode(t.Fields[name]))
+	}
+	return names, arguments
+}
+
+func argumentCode(arg interface{}) string {
+	switch a := arg.(type) {
+	case nil:
+		return "nil"
+	case string:
+		return fmt.Sprintf(`"%s"`, a)
+	default:
+		return fmt.Sprintf("%v", a)
+	}
+}
@@ -111,6 +111,79 @@ var _ = Describe("TypeDefinition", func() {
 			}
 			Expect(t.Validate("foobar")).To(Succeed())
 		})
+
+		It("should not return an error if the scope is known", func() {
+			t := main.TypeDefinition{
+				Package:       "foo/bar",
+				FactoryMethod: "NewBaz",
+				Scope:         "prototype",
+			}
+			Expect(t.Validate("foobar")).To(Succeed())
+		})
+
+		It("should return an error if a tag has no name", func() {
+			t := main.TypeDefinition{
+				Package:       "foo/bar",
+				FactoryMethod: "NewBaz",
+				Tags:          []main.TagDefinition{{Name: "foo"}, {Priority: 10}},
+			}
+			Expect(t.Validate("foobar")).To(MatchError(`type definition of "foobar" contains a tag without name`))
+		})
+
+		It("should return an error if a tag has non scalar attributes", func() {
+			t := main.TypeDefinition{
+				Package:       "foo/bar",
+				FactoryMethod: "NewBaz",
+				Tags:          []main.TagDefinition{{Name: "foo", Attributes: map[string]interface{}{"bar": []interface{}{1, 2}}}},
+			}
+			Expect(t.Validate("foobar")).To(MatchError(`tag "foo" of type "foobar" has attribute "bar" with unsupported value [1 2]`))
+		})
+
+		It("should return an error if a decoration priority is given without decorated type", func() {
+			t := main.TypeDefinition{
+				Package:            "foo/bar",
+				FactoryMethod:      "NewBaz",
+				DecorationPriority: 5,
+			}
+			Expect(t.Validate("foobar")).To(MatchError(`type definition of "foobar" has a decoration priority but does not decorate any type`))
+		})
+
+		It("should return an error if a type without factory function is autowired", func() {
+			t := main.TypeDefinition{
+				Package:  "foo/bar",
+				TypeName: "Baz",
+				Autowire: true,
+			}
+			Expect(t.Validate("foobar")).To(MatchError(`type definition of "foobar" can only be autowired if it uses a factory function`))
+		})
+
+		It("should return an error if a type with fields is no struct type", func() {
+			t := main.TypeDefinition{
+				Package:       "foo/bar",
+				FactoryMethod: "NewBaz",
+				Fields:        map[string]interface{}{"Name": "foo"},
+			}
+			Expect(t.Validate("foobar")).To(MatchError(`type definition of "foobar" has fields but is no struct type`))
+		})
+
+		It("should return an error if a struct type has both fields and arguments", func() {
+			t := main.TypeDefinition{
+				Package:      "foo/bar",
+				TypeName:     "Baz",
+				Fields:       map[string]interface{}{"Name": "foo"},
+				RawArguments: []interface{}{"foo"},
+			}
+			Expect(t.Validate("foobar")).To(MatchError(`type definition of "foobar" can not have both fields and arguments. Please decide for one of them`))
+		})
+
+		It("should return an error if the scope is unknown", func() {
+			t := main.TypeDefinition{
+				Package:       "foo/bar",
+				FactoryMethod: "NewBaz",
+				Scope:         "session",
+			}
+			Expect(t.Validate("foobar")).To(MatchError(`type definition of "foobar" has unknown scope "session"`))
+		})
 	})
 
 	Describe("PackageName", func() {
@@ -2,6 +2,9 @@ package main
 
 import (
 	"fmt"
+	"maps"
+	"slices"
+	"strconv"
 	"strings"
 )
 
@@ -34,9 +37,55 @@ func FactoryCode(t TypeDefinition, outputPackageName string) string {
 		typeFactoryCode = fmt.Sprintf("goldi.NewConfiguredType(\n\t\t%s,\n\t\t%q, %q,\n\t)", typeFactoryCode, configuratorID, configuratorMethod)
 	}
 
+	if decoratedTypeID := strings.TrimPrefix(strings.TrimSpace(t.Decorates), "@"); decoratedTypeID != "" {
+		typeFactoryCode = fmt.Sprintf("goldi.NewDecoratorType(%s, %q, %d)", typeFactoryCode, decoratedTypeID, t.DecorationPriority)
+	}
+
+	if len(t.Tags) > 0 {
+		tags := []string{typeFactoryCode}
+		for _, tag := range t.Tags {
+			tags = append(tags, tagCode(tag))
+		}
+
+		typeFactoryCode = fmt.Sprintf("goldi.NewTaggedType(%s)", strings.Join(tags, ", "))
+	}
+
+	if t.Scope != "" && t.Scope != "singleton" {
+		typeFactoryCode = fmt.Sprintf("goldi.NewScopedType(%s, %s)", typeFactoryCode, scopeConstants[t.Scope])
+	}
+
+	if t.Eager {
+		typeFactoryCode = fmt.Sprintf("goldi.NewEagerType(%s)", typeFactoryCode)
+	}
+
 	return typeFactoryCode
 }
 
+// scopeConstants maps the supported values of the "scope" key to the corresponding goldi.Scope constant.
+var scopeConstants = map[string]string{
+	"singleton": "goldi.Singleton",
+	"prototype": "goldi.Prototype",
+	"scoped":    "goldi.Scoped",
+}
+
+func tagCode(tag TagDefinition) string {
+	fields := []string{fmt.Sprintf("Name: %q", tag.Name)}
+	if tag.Priority != 0 {
+		fields = append(fields, fmt.Sprintf("Priority: %d", tag.Priority))
+	}
+
+	if len(tag.Attributes) > 0 {
+		attributes := make([]string, 0, len(tag.Attributes))
+		for _, key := range slices.Sorted(maps.Keys(tag.Attributes)) {
+			attributes = append(attributes, fmt.Sprintf("%q: %#v", key, tag.Attributes[key]))
+		}
+
+		fields = append(fields, fmt.Sprintf("Attributes: map[string]interface{}{%s}", strings.Join(attributes, ", ")))
+	}
+
+	return fmt.Sprintf("goldi.Tag{%s}", strings.Join(fields, ", "))
+}
+
 func funcTypeCode(t TypeDefinition, outputPackageName string) string {
 	funcName := t.FuncName
 	if t.Package != outputPackageName {
@@ -67,6 +116,10 @@ func factoryTypeCode(t TypeDefinition, outputPackageName string) string {
 
 	arguments := []string{factoryMethod}
 	arguments = append(arguments, t.Arguments()...)
+	if t.Autowire {
+		return fmt.Sprintf("goldi.NewAutowiredType(%s)", strings.Join(arguments, ", "))
+	}
+
 	return fmt.Sprintf("goldi.NewType(%s)", strings.Join(arguments, ", "))
 }
 
@@ -77,7 +130,18 @@ func structTypeCode(t TypeDefinition, outputPackageName string) string {
 	}
 
 	arguments := []string{factoryMethod}
-	arguments = append(arguments, t.Arguments()...)
+	if len(t.Fields) > 0 {
+		names, fieldArguments := t.FieldArguments()
+		fields := make([]string, len(names))
+		for i, name := range names {
+			fields[i] = fmt.Sprintf("%q: %s", name, fieldArguments[i])
+		}
+
+		arguments = append(arguments, fmt.Sprintf("map[string]interface{}{%s}", strings.Join(fields, ", ")))
+	} else {
+		arguments = append(arguments, t.Arguments()...)
+	}
+
 	return fmt.Sprintf("goldi.NewStructType(%s)", strings.Join(arguments, ", "))
 }
 
@@ -87,3 +151,41 @@ func proxyTypeCode(t TypeDefinition) string {
 	arguments := append([]string{fmt.Sprintf("%q", parts[0]), fmt.Sprintf("%q", parts[1])}, t.Arguments()...)
 	return fmt.Sprintf("goldi.NewProxyType(%s)", strings.Join(arguments, ", "))
 }
+
+// ParameterCode returns the go literal of a parameter value as it has been parsed from the yaml file.
+// Lists become []interface{} and maps become map[string]interface{} so the values can be used with dotted parameter
+// paths like %database.host%.
+func ParameterCode(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return "nil"
+	case string:
+		return strconv.Quote(v)
+	case float64:
+		code := strconv.FormatFloat(v, 'g', -1, 64)
+		if strings.ContainsAny(code, ".e") == false {
+			code += ".0" // make sure the literal is not turned into an int
+		}
+		return code
+	case []interface{}:
+		elements := make([]string, len(v))
+		for i, element := range v {
+			elements[i] = ParameterCode(element)
+		}
+		return fmt.Sprintf("[]interface{}{%s}", strings.Join(elements, ", "))
+	case map[interface{}]interface{}:
+		values := make(map[string]interface{}, len(v))
+		for key, element := range v {
+			values[fmt.Sprint(key)] = element
+		}
+		return ParameterCode(values)
+	case map[string]interface{}:
+		elements := make([]string, 0, len(v))
+		for _, key := range slices.Sorted(maps.Keys(v)) {
+			elements = append(elements, fmt.Sprintf("%q: %s", key, ParameterCode(v[key])))
+		}
+		return fmt.Sprintf("map[string]interface{}{%s}", strings.Join(elements, ", "))
+	default:
+		return fmt.Sprintf("%#v", v)
+	}
+}
@@ -17,6 +17,15 @@ var _ = Describe("FactoryCode", func() {
 		Expect(main.FactoryCode(typeDef, typeDef.Package)).To(Equal(`goldi.NewStructType(new(Baz), "foo", "%bar%", 42)`))
 	})
 
+	It("should return the golang code to register a struct type with named fields", func() {
+		typeDef := main.TypeDefinition{
+			Package:  "foo/bar",
+			TypeName: "Baz",
+			Fields:   map[string]interface{}{"Name": "foo", "Logger": "@logger", "Retries": 3},
+		}
+		Expect(main.FactoryCode(typeDef, "some/package/lib")).To(Equal(`goldi.NewStructType(new(bar.Baz), map[string]interface{}{"Logger": "@logger", "Name": "foo", "Retries": 3})`))
+	})
+
 	It("should return the golang code to register a type using a factory function", func() {
 		typeDef := main.TypeDefinition{
 			Package:       "foo/bar",
@@ -58,8 +67,103 @@ var _ = Describe("FactoryCode", func() {
 		Expect(main.FactoryCode(typeDef, "some/package/lib")).To(Equal(`goldi.NewProxyType("logger_provider", "GetLogger", "foo", "%bar%", 42)`))
 	})
 
+	It("should return the golang code to register a prototype type", func() {
+		typeDef := main.TypeDefinition{
+			Package:       "foo/bar",
+			FactoryMethod: "NewBaz",
+			Scope:         "prototype",
+		}
+		Expect(main.FactoryCode(typeDef, "some/package/lib")).To(Equal(`goldi.NewScopedType(goldi.NewType(bar.NewBaz), goldi.Prototype)`))
+	})
+
+	It("should return the golang code to register a scoped type", func() {
+		typeDef := main.TypeDefinition{
+			Package:  "foo/bar",
+			TypeName: "Baz",
+			Scope:    "scoped",
+		}
+		Expect(main.FactoryCode(typeDef, "some/package/lib")).To(Equal(`goldi.NewScopedType(goldi.NewStructType(new(bar.Baz)), goldi.Scoped)`))
+	})
+
+	It("should return the golang code to register an eager type", func() {
+		typeDef := main.TypeDefinition{
+			Package:       "foo/bar",
+			FactoryMethod: "NewServer",
+			Eager:         true,
+		}
+		Expect(main.FactoryCode(typeDef, "some/package/lib")).To(Equal(`goldi.NewEagerType(goldi.NewType(bar.NewServer))`))
+	})
+
+	It("should return the golang code to register a tagged type", func() {
+		typeDef := main.TypeDefinition{
+			Package:       "foo/bar",
+			FactoryMethod: "NewMiddleware",
+			Tags: []main.TagDefinition{
+				{Name: "http.middleware"},
+				{Name: "health_check", Priority: 10, Attributes: map[string]interface{}{"path": "/health", "critical": true}},
+			},
+			Scope: "prototype",
+		}
+		Expect(main.FactoryCode(typeDef, "some/package/lib")).To(Equal(
+			`goldi.NewScopedType(goldi.NewTaggedType(goldi.NewType(bar.NewMiddleware), ` +
+				`goldi.Tag{Name: "http.middleware"}, ` +
+				`goldi.Tag{Name: "health_check", Priority: 10, Attributes: map[string]interface{}{"critical": true, "path": "/health"}}), goldi.Prototype)`,
+		))
+	})
+
+	It("should return the golang code to register a decorator", func() {
+		typeDef := main.TypeDefinition{
+			Package:            "foo/bar",
+			FactoryMethod:      "NewTracingLogger",
+			RawArguments:       []interface{}{"@.inner"},
+			Decorates:          "@logger",
+			DecorationPriority: 10,
+		}
+		Expect(main.FactoryCode(typeDef, "some/package/lib")).To(Equal(`goldi.NewDecoratorType(goldi.NewType(bar.NewTracingLogger, "@.inner"), "logger", 10)`))
+	})
+
+	It("should return the golang code to register an autowired type", func() {
+		typeDef := main.TypeDefinition{
+			Package:       "foo/bar",
+			FactoryMethod: "NewService",
+			Autowire:      true,
+			RawArguments:  []interface{}{nil, "%name%"},
+		}
+		Expect(main.FactoryCode(typeDef, "some/package/lib")).To(Equal(`goldi.NewAutowiredType(bar.NewService, nil, "%name%")`))
+	})
+
+	It("should not wrap singleton types", func() {
+		typeDef := main.TypeDefinition{
+			Package:       "foo/bar",
+			FactoryMethod: "NewBaz",
+			Scope:         "singleton",
+		}
+		Expect(main.FactoryCode(typeDef, "some/package/lib")).To(Equal(`goldi.NewType(bar.NewBaz)`))
+	})
+
 	It("should panic when type definition is not configured", func() {
 		typeDef := main.TypeDefinition{}
 		Expect(func() { main.FactoryCode(typeDef, "some/package/lib") }).To(Panic())
 	})
 })
+
+var _ = Describe("ParameterCode", func() {
+	It("should return typed go literals", func() {
+		Expect(main.ParameterCode("foo")).To(Equal(`"foo"`))
+		Expect(main.ParameterCode("say \"hi\"")).To(Equal(`"say \"hi\""`))
+		Expect(main.ParameterCode(42)).To(Equal(`42`))
+		Expect(main.ParameterCode(true)).To(Equal(`true`))
+		Expect(main.ParameterCode(1.5)).To(Equal(`1.5`))
+		Expect(main.ParameterCode(nil)).To(Equal(`nil`))
+	})
+
+	It("should not turn whole floats into integers", func() {
+		Expect(main.ParameterCode(2.0)).To(Equal(`2.0`))
+	})
+
+	It("should return the code of lists and maps", func() {
+		Expect(main.ParameterCode([]interface{}{"a", 1})).To(Equal(`[]interface{}{"a", 1}`))
+		Expect(main.ParameterCode(map[interface{}]interface{}{"port": 5432, "host": "localhost", "tags": []interface{}{true}})).
+			To(Equal(`map[string]interface{}{"host": "localhost", "port": 5432, "tags": []interface{}{true}}`))
+	})
+})
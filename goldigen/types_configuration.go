@@ -10,7 +10,7 @@ import (
 // The TypesConfiguration is the struct that holds the complete dependency injection configuration
 // as parsed from a yaml file
 type TypesConfiguration struct {
-	Parameters map[string]string         `yaml:"parameters,omitempty"`
+	Parameters map[string]interface{}    `yaml:"parameters,omitempty"`
 	Types      map[string]TypeDefinition `yaml:"types,omitempty"`
 }
 
@@ -0,0 +1,184 @@
+// Package graph builds the dependency graph of the types of a goldi.TypeRegistry and renders it
+// as Graphviz DOT, Mermaid or JSON.
+package graph
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+
+	"github.com/tarokamikaze/goldi"
+)
+
+// Undefined is the kind of nodes that are referenced by other types but have not been registered.
+const Undefined goldi.FactoryKind = "undefined"
+
+// A Node represents a single type of the registry.
+type Node struct {
+	ID       string            `json:"id"`
+	Kind     goldi.FactoryKind `json:"kind"`
+	Package  string            `json:"package,omitempty"`
+	Function string            `json:"function,omitempty"`
+	Scope    goldi.Scope       `json:"scope,omitempty"`
+	Tags     []string          `json:"tags,omitempty"`
+	Error    string            `json:"error,omitempty"`
+}
+
+// An Edge points from a type to a type it depends on.
+// Tagged references result in one edge to each type that has been tagged with the referenced tag and autowired
+// arguments in one edge to each type that is assignable to the argument (see goldi.TypeRegistry.AutowireCandidates).
+type Edge struct {
+	From   string               `json:"from"`
+	To     string               `json:"to"`
+	Kind   goldi.DependencyKind `json:"kind"`
+	Method string               `json:"method,omitempty"`
+	Tag    string               `json:"tag,omitempty"`
+}
+
+// A Graph contains the nodes sorted by their ID and the edges sorted by their source and target.
+type Graph struct {
+	Nodes []Node `json:"nodes"`
+	Edges []Edge `json:"edges"`
+}
+
+// New builds the dependency graph of all types of the given registry (see goldi.DescribeType).
+// Types that are referenced but have not been registered are added as nodes of the kind Undefined.
+// If an autowired argument does not have exactly one candidate, the error of the node describes the problem.
+func New(registry goldi.TypeRegistry) *Graph {
+	descriptions := make(map[string]goldi.TypeDescription, len(registry))
+	taggedTypeIDs := map[string][]string{}
+	for typeID, generator := range registry {
+		description := goldi.DescribeType(generator)
+		descriptions[typeID] = description
+		for _, tag := range description.Tags {
+			taggedTypeIDs[tag.Name] = append(taggedTypeIDs[tag.Name], typeID)
+		}
+	}
+
+	g := &Graph{Nodes: []Node{}, Edges: []Edge{}}
+	for typeID, description := range descriptions {
+		node := newNode(typeID, description)
+		for _, dependency := range description.Dependencies {
+			switch dependency.Kind {
+			case goldi.TaggedDependency:
+				for _, taggedTypeID := range taggedTypeIDs[dependency.Tag] {
+					g.Edges = append(g.Edges, Edge{From: typeID, To: taggedTypeID, Kind: dependency.Kind, Tag: dependency.Tag})
+				}
+			case goldi.AutowiredDependency:
+				candidates := registry.AutowireCandidates(dependency.Type)
+				for _, candidate := range candidates {
+					g.Edges = append(g.Edges, Edge{From: typeID, To: candidate, Kind: dependency.Kind})
+				}
+
+				switch len(candidates) {
+				case 0:
+					node.addError(fmt.Sprintf("no registered type is assignable to %v", dependency.Type))
+				case 1:
+				default:
+					node.addError(fmt.Sprintf("%d registered types are assignable to %v", len(candidates), dependency.Type))
+				}
+			default:
+				g.Edges = append(g.Edges, Edge{From: typeID, To: dependency.TypeID, Kind: dependency.Kind, Method: dependency.Method})
+			}
+		}
+
+		g.Nodes = append(g.Nodes, node)
+	}
+
+	for _, edge := range g.Edges {
+		if _, isRegistered := descriptions[edge.To]; isRegistered == false {
+			descriptions[edge.To] = goldi.TypeDescription{Kind: Undefined}
+			g.Nodes = append(g.Nodes, Node{ID: edge.To, Kind: Undefined})
+		}
+	}
+
+	g.sort()
+	return g
+}
+
+func newNode(typeID string, description goldi.TypeDescription) Node {
+	node := Node{
+		ID:       typeID,
+		Kind:     description.Kind,
+		Package:  description.Package,
+		Function: description.Function,
+		Scope:    description.Scope,
+	}
+
+	for _, tag := range description.Tags {
+		node.Tags = append(node.Tags, tag.Name)
+	}
+
+	if description.Err != nil {
+		node.Error = description.Err.Error()
+	}
+
+	return node
+}
+
+// addError adds the given message to the error of the node.
+func (n *Node) addError(message string) {
+	if n.Error != "" {
+		message = n.Error + "; " + message
+	}
+
+	n.Error = message
+}
+
+// Subgraph returns the part of the graph that is reachable from the given type.
+// It contains all direct and transitive dependencies of the type as well as the decorators of these types
+// because they take part in generating them. An error is returned if the type is not part of the graph.
+func (g *Graph) Subgraph(typeID string) (*Graph, error) {
+	if slices.ContainsFunc(g.Nodes, func(n Node) bool { return n.ID == typeID }) == false {
+		return nil, fmt.Errorf("graph: type %q is not part of the graph", typeID)
+	}
+
+	neighbours := map[string][]string{}
+	for _, edge := range g.Edges {
+		neighbours[edge.From] = append(neighbours[edge.From], edge.To)
+		if edge.Kind == goldi.DecoratorDependency {
+			neighbours[edge.To] = append(neighbours[edge.To], edge.From)
+		}
+	}
+
+	reachable := map[string]bool{typeID: true}
+	queue := []string{typeID}
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+		for _, neighbour := range neighbours[current] {
+			if reachable[neighbour] == false {
+				reachable[neighbour] = true
+				queue = append(queue, neighbour)
+			}
+		}
+	}
+
+	subgraph := &Graph{Nodes: []Node{}, Edges: []Edge{}}
+	for _, node := range g.Nodes {
+		if reachable[node.ID] {
+			subgraph.Nodes = append(subgraph.Nodes, node)
+		}
+	}
+
+	for _, edge := range g.Edges {
+		if reachable[edge.From] && reachable[edge.To] {
+			subgraph.Edges = append(subgraph.Edges, edge)
+		}
+	}
+
+	return subgraph, nil
+}
+
+func (g *Graph) sort() {
+	slices.SortFunc(g.Nodes, func(a, b Node) int { return cmp.Compare(a.ID, b.ID) })
+	slices.SortFunc(g.Edges, func(a, b Edge) int {
+		return cmp.Or(
+			cmp.Compare(a.From, b.From),
+			cmp.Compare(a.To, b.To),
+			cmp.Compare(a.Kind, b.Kind),
+			cmp.Compare(a.Method, b.Method),
+			cmp.Compare(a.Tag, b.Tag),
+		)
+	})
+}
@@ -0,0 +1,168 @@
+package graph_test
+
+import (
+	"fmt"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/tarokamikaze/goldi"
+	"github.com/tarokamikaze/goldi/graph"
+)
+
+func ExampleGraph_Mermaid() {
+	registry := goldi.NewTypeRegistry()
+	registry.RegisterType("logger", NewLogger, "%log.level%")
+	registry.RegisterType("handler", NewHandler, "@logger", "!tagged middleware", "@?cache")
+
+	fmt.Print(graph.New(registry).Mermaid())
+	// Output:
+	// graph LR
+	// 	n0["cache<br/>undefined"]
+	// 	n1["handler<br/>type: github.com/tarokamikaze/goldi/graph_test.NewHandler"]
+	// 	n2["logger<br/>type: github.com/tarokamikaze/goldi/graph_test.NewLogger"]
+	// 	n1 -.->|"optional"| n0
+	// 	n1 --> n2
+}
+
+var _ = Describe("Graph", func() {
+	const testPackage = "github.com/tarokamikaze/goldi/graph_test"
+
+	var registry goldi.TypeRegistry
+
+	BeforeEach(func() {
+		registry = goldi.NewTypeRegistry()
+		registry.Register("logger", goldi.NewConfiguredType(goldi.NewType(NewLogger, "%log.level%"), "logger_configurator", "Configure"))
+		registry.RegisterType("logger_configurator", &LoggerConfigurator{})
+		registry.RegisterType("handler", NewHandler, "@logger", "!tagged middleware", "@?cache")
+		registry.Register("auth", goldi.NewTaggedType(goldi.NewType(NewMiddleware, "auth"), goldi.Tag{Name: "middleware"}))
+		registry.Register("cors", goldi.NewTaggedType(goldi.NewType(NewMiddleware, "cors"), goldi.Tag{Name: "middleware"}))
+		registry.Register("http_handler", goldi.NewFuncReferenceType("handler", "ServeHTTP"))
+		registry.Register("request_logger", goldi.NewScopedType(goldi.NewProxyType("logger", "Named", "request"), goldi.Prototype))
+		registry.Register("default_logger", goldi.NewAliasType("logger"))
+		registry.Register("prefixed_logger", goldi.NewDecoratorType(goldi.NewType(NewPrefixedLogger, "@.inner", "%log.prefix%"), "logger", 0))
+		registry.InjectInstance("unrelated", &Middleware{Name: "unrelated"})
+	})
+
+	Describe("New", func() {
+		It("should annotate the nodes with the kind, package and function of their factory", func() {
+			g := graph.New(registry)
+			Expect(g.Nodes).To(ContainElement(graph.Node{ID: "logger", Kind: goldi.KindType, Package: testPackage, Function: "NewLogger", Scope: goldi.Singleton}))
+			Expect(g.Nodes).To(ContainElement(graph.Node{ID: "logger_configurator", Kind: goldi.KindStruct, Package: testPackage, Function: "LoggerConfigurator", Scope: goldi.Singleton}))
+			Expect(g.Nodes).To(ContainElement(graph.Node{ID: "auth", Kind: goldi.KindType, Package: testPackage, Function: "NewMiddleware", Scope: goldi.Singleton, Tags: []string{"middleware"}}))
+			Expect(g.Nodes).To(ContainElement(graph.Node{ID: "request_logger", Kind: goldi.KindProxy, Function: "Named", Scope: goldi.Prototype}))
+			Expect(g.Nodes).To(ContainElement(graph.Node{ID: "unrelated", Kind: goldi.KindInstance, Package: testPackage, Function: "Middleware", Scope: goldi.Singleton}))
+		})
+
+		It("should add all types and referenced undefined types as nodes sorted by their ID", func() {
+			var nodeIDs []string
+			for _, node := range graph.New(registry).Nodes {
+				nodeIDs = append(nodeIDs, node.ID)
+			}
+
+			Expect(nodeIDs).To(Equal([]string{
+				"auth", "cache", "cors", "default_logger", "handler", "http_handler",
+				"logger", "logger_configurator", "prefixed_logger", "request_logger", "unrelated",
+			}))
+			Expect(graph.New(registry).Nodes[1]).To(Equal(graph.Node{ID: "cache", Kind: graph.Undefined}))
+		})
+
+		It("should add an edge for each dependency", func() {
+			Expect(graph.New(registry).Edges).To(Equal([]graph.Edge{
+				{From: "default_logger", To: "logger", Kind: goldi.AliasDependency},
+				{From: "handler", To: "auth", Kind: goldi.TaggedDependency, Tag: "middleware"},
+				{From: "handler", To: "cache", Kind: goldi.OptionalDependency},
+				{From: "handler", To: "cors", Kind: goldi.TaggedDependency, Tag: "middleware"},
+				{From: "handler", To: "logger", Kind: goldi.ReferenceDependency},
+				{From: "http_handler", To: "handler", Kind: goldi.FuncReferenceDependency, Method: "ServeHTTP"},
+				{From: "logger", To: "logger_configurator", Kind: goldi.ConfiguratorDependency, Method: "Configure"},
+				{From: "prefixed_logger", To: "logger", Kind: goldi.DecoratorDependency},
+				{From: "request_logger", To: "logger", Kind: goldi.ProxyDependency, Method: "Named"},
+			}))
+		})
+
+		It("should add edges for the goldi tags of struct types", func() {
+			registry.Register("api", goldi.NewStructType(&API{}))
+
+			g := graph.New(registry)
+			Expect(g.Edges).To(ContainElement(graph.Edge{From: "api", To: "logger", Kind: goldi.ReferenceDependency}))
+			Expect(g.Edges).To(ContainElement(graph.Edge{From: "api", To: "cache", Kind: goldi.OptionalDependency}))
+		})
+
+		It("should add an edge to each candidate of an autowired argument", func() {
+			registry.Register("api", goldi.NewAutowiredType(NewAPI))
+
+			g := graph.New(registry)
+			Expect(g.Edges).To(ContainElement(graph.Edge{From: "api", To: "logger", Kind: goldi.AutowiredDependency}))
+			Expect(g.Edges).To(ContainElement(graph.Edge{From: "api", To: "handler", Kind: goldi.AutowiredDependency}))
+			Expect(g.Nodes).To(ContainElement(SatisfyAll(HaveField("ID", "api"), HaveField("Error", BeEmpty()))))
+		})
+
+		It("should annotate autowired types whose arguments do not have exactly one candidate", func() {
+			registry.Register("authenticator", goldi.NewAutowiredType(NewAuthenticator, "@logger"))
+
+			g := graph.New(registry)
+			Expect(g.Edges).To(ContainElements(
+				graph.Edge{From: "authenticator", To: "logger", Kind: goldi.ReferenceDependency},
+				graph.Edge{From: "authenticator", To: "auth", Kind: goldi.AutowiredDependency},
+				graph.Edge{From: "authenticator", To: "authenticator", Kind: goldi.AutowiredDependency},
+				graph.Edge{From: "authenticator", To: "cors", Kind: goldi.AutowiredDependency},
+				graph.Edge{From: "authenticator", To: "unrelated", Kind: goldi.AutowiredDependency},
+			))
+			Expect(g.Nodes).To(ContainElement(SatisfyAll(
+				HaveField("ID", "authenticator"),
+				HaveField("Error", "4 registered types are assignable to *graph_test.Middleware; no registered type is assignable to *graph_test.Cache"),
+			)))
+		})
+
+		It("should annotate invalid types with their error", func() {
+			registry.Register("invalid", goldi.NewType(42))
+
+			g := graph.New(registry)
+			Expect(g.Nodes).To(ContainElement(SatisfyAll(
+				HaveField("ID", "invalid"),
+				HaveField("Kind", goldi.KindInvalid),
+				HaveField("Error", Not(BeEmpty())),
+			)))
+		})
+
+		It("should build an empty graph from an empty registry", func() {
+			g := graph.New(goldi.NewTypeRegistry())
+			Expect(g.Nodes).To(BeEmpty())
+			Expect(g.Edges).To(BeEmpty())
+		})
+	})
+
+	Describe("Subgraph", func() {
+		It("should only contain the types that are reachable from the given type and their decorators", func() {
+			subgraph, err := graph.New(registry).Subgraph("handler")
+			Expect(err).NotTo(HaveOccurred())
+
+			var nodeIDs []string
+			for _, node := range subgraph.Nodes {
+				nodeIDs = append(nodeIDs, node.ID)
+			}
+
+			Expect(nodeIDs).To(Equal([]string{"auth", "cache", "cors", "handler", "logger", "logger_configurator", "prefixed_logger"}))
+			Expect(subgraph.Edges).To(Equal([]graph.Edge{
+				{From: "handler", To: "auth", Kind: goldi.TaggedDependency, Tag: "middleware"},
+				{From: "handler", To: "cache", Kind: goldi.OptionalDependency},
+				{From: "handler", To: "cors", Kind: goldi.TaggedDependency, Tag: "middleware"},
+				{From: "handler", To: "logger", Kind: goldi.ReferenceDependency},
+				{From: "logger", To: "logger_configurator", Kind: goldi.ConfiguratorDependency, Method: "Configure"},
+				{From: "prefixed_logger", To: "logger", Kind: goldi.DecoratorDependency},
+			}))
+		})
+
+		It("should contain only the type itself if it has no dependencies", func() {
+			subgraph, err := graph.New(registry).Subgraph("unrelated")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(subgraph.Nodes).To(HaveLen(1))
+			Expect(subgraph.Edges).To(BeEmpty())
+		})
+
+		It("should return an error if the type is not part of the graph", func() {
+			_, err := graph.New(registry).Subgraph("foo")
+			Expect(err).To(MatchError(`graph: type "foo" is not part of the graph`))
+		})
+	})
+})
@@ -0,0 +1,136 @@
+package graph
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/tarokamikaze/goldi"
+)
+
+// DOT renders the graph in the Graphviz DOT language.
+// Optional dependencies and undefined types are drawn dashed and all edges except plain type references are labeled.
+//
+//	dot -Tsvg -o dependencies.svg dependencies.dot
+func (g *Graph) DOT() string {
+	var b strings.Builder
+	b.WriteString("digraph goldi {\n")
+	b.WriteString("\trankdir=LR;\n")
+	b.WriteString("\tnode [shape=box];\n")
+
+	for _, node := range g.Nodes {
+		fmt.Fprintf(&b, "\t%s [label=%s", dotQuote(node.ID), dotQuote(nodeLabel(node)...))
+		if node.Kind == Undefined {
+			b.WriteString(", style=dashed")
+		}
+		b.WriteString("];\n")
+	}
+
+	for _, edge := range g.Edges {
+		var attributes []string
+		if label := edgeLabel(edge); label != "" {
+			attributes = append(attributes, "label="+dotQuote(label))
+		}
+		if edge.Kind == goldi.OptionalDependency {
+			attributes = append(attributes, "style=dashed")
+		}
+
+		fmt.Fprintf(&b, "\t%s -> %s", dotQuote(edge.From), dotQuote(edge.To))
+		if len(attributes) > 0 {
+			fmt.Fprintf(&b, " [%s]", strings.Join(attributes, ", "))
+		}
+		b.WriteString(";\n")
+	}
+
+	b.WriteString("}\n")
+	return b.String()
+}
+
+// Mermaid renders the graph as Mermaid flowchart which can be embedded in Markdown documents.
+// The nodes are named n0, n1, ... in the order of the graph because type IDs may contain characters that Mermaid
+// does not allow in node names. Optional dependencies are drawn as dotted arrows.
+func (g *Graph) Mermaid() string {
+	var b strings.Builder
+	b.WriteString("graph LR\n")
+
+	names := make(map[string]string, len(g.Nodes))
+	for i, node := range g.Nodes {
+		names[node.ID] = fmt.Sprintf("n%d", i)
+		fmt.Fprintf(&b, "\t%s[%s]\n", names[node.ID], mermaidQuote(nodeLabel(node)...))
+	}
+
+	for _, edge := range g.Edges {
+		arrow := "-->"
+		if edge.Kind == goldi.OptionalDependency {
+			arrow = "-.->"
+		}
+
+		if label := edgeLabel(edge); label != "" {
+			arrow += "|" + mermaidQuote(label) + "|"
+		}
+
+		fmt.Fprintf(&b, "\t%s %s %s\n", names[edge.From], arrow, names[edge.To])
+	}
+
+	return b.String()
+}
+
+// JSON renders the nodes and edges of the graph as indented JSON.
+func (g *Graph) JSON() ([]byte, error) {
+	return json.MarshalIndent(g, "", "  ")
+}
+
+// nodeLabel returns the lines that describe a node: its ID, its kind with the qualified factory function and
+// its scope if it is not a singleton.
+func nodeLabel(node Node) []string {
+	kind := string(node.Kind)
+	switch {
+	case node.Package != "":
+		kind += ": " + node.Package + "." + node.Function
+	case node.Function != "":
+		kind += ": " + node.Function
+	}
+
+	lines := []string{node.ID, kind}
+	if node.Scope != "" && node.Scope != goldi.Singleton {
+		lines = append(lines, string(node.Scope))
+	}
+
+	return lines
+}
+
+// edgeLabel returns the kind of the edge together with its method or tag or nothing for plain type references.
+func edgeLabel(edge Edge) string {
+	if edge.Kind == goldi.ReferenceDependency {
+		return ""
+	}
+
+	label := string(edge.Kind)
+	for _, detail := range []string{edge.Method, edge.Tag} {
+		if detail != "" {
+			label += " " + detail
+		}
+	}
+
+	return label
+}
+
+// dotQuote returns the given lines as a single quoted DOT string.
+func dotQuote(lines ...string) string {
+	escaper := strings.NewReplacer(`\`, `\\`, `"`, `\"`)
+	for i, line := range lines {
+		lines[i] = escaper.Replace(line)
+	}
+
+	return `"` + strings.Join(lines, `\n`) + `"`
+}
+
+// mermaidQuote returns the given lines as a single quoted Mermaid label.
+func mermaidQuote(lines ...string) string {
+	escaper := strings.NewReplacer(`"`, "#quot;", "<", "#lt;", ">", "#gt;")
+	for i, line := range lines {
+		lines[i] = escaper.Replace(line)
+	}
+
+	return `"` + strings.Join(lines, "<br/>") + `"`
+}
@@ -0,0 +1,94 @@
+package graph_test
+
+import (
+	"encoding/json"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/tarokamikaze/goldi"
+	"github.com/tarokamikaze/goldi/graph"
+)
+
+var _ = Describe("Rendering", func() {
+	var g *graph.Graph
+
+	BeforeEach(func() {
+		registry := goldi.NewTypeRegistry()
+		registry.Register("logger", goldi.NewScopedType(goldi.NewType(NewLogger, "%log.level%"), goldi.Prototype))
+		registry.RegisterType("handler", NewHandler, "@logger", "!tagged middleware", "@?cache")
+		registry.Register("auth", goldi.NewTaggedType(goldi.NewType(NewMiddleware, "auth"), goldi.Tag{Name: "middleware"}))
+		registry.Register("http_handler", goldi.NewFuncReferenceType("handler", "ServeHTTP"))
+		g = graph.New(registry)
+	})
+
+	Describe("DOT", func() {
+		It("should render the nodes and edges", func() {
+			Expect(g.DOT()).To(Equal(`digraph goldi {
+	rankdir=LR;
+	node [shape=box];
+	"auth" [label="auth\ntype: github.com/tarokamikaze/goldi/graph_test.NewMiddleware"];
+	"cache" [label="cache\nundefined", style=dashed];
+	"handler" [label="handler\ntype: github.com/tarokamikaze/goldi/graph_test.NewHandler"];
+	"http_handler" [label="http_handler\nfunc_reference: ServeHTTP"];
+	"logger" [label="logger\ntype: github.com/tarokamikaze/goldi/graph_test.NewLogger\nprototype"];
+	"handler" -> "auth" [label="tagged middleware"];
+	"handler" -> "cache" [label="optional", style=dashed];
+	"handler" -> "logger";
+	"http_handler" -> "handler" [label="func_reference ServeHTTP"];
+}
+`))
+		})
+
+		It("should escape quotes and backslashes", func() {
+			registry := goldi.NewTypeRegistry()
+			registry.Register(`say "hi"\`, goldi.NewAliasType("logger"))
+
+			Expect(graph.New(registry).DOT()).To(ContainSubstring(`"say \"hi\"\\" -> "logger" [label="alias"];`))
+		})
+	})
+
+	Describe("Mermaid", func() {
+		It("should render the nodes and edges", func() {
+			Expect(g.Mermaid()).To(Equal(`graph LR
+	n0["auth<br/>type: github.com/tarokamikaze/goldi/graph_test.NewMiddleware"]
+	n1["cache<br/>undefined"]
+	n2["handler<br/>type: github.com/tarokamikaze/goldi/graph_test.NewHandler"]
+	n3["http_handler<br/>func_reference: ServeHTTP"]
+	n4["logger<br/>type: github.com/tarokamikaze/goldi/graph_test.NewLogger<br/>prototype"]
+	n2 -->|"tagged middleware"| n0
+	n2 -.->|"optional"| n1
+	n2 --> n4
+	n3 -->|"func_reference ServeHTTP"| n2
+`))
+		})
+
+		It("should escape characters that Mermaid does not allow in labels", func() {
+			registry := goldi.NewTypeRegistry()
+			registry.Register(`<say "hi">`, goldi.NewAliasType("logger"))
+
+			Expect(graph.New(registry).Mermaid()).To(ContainSubstring(`["#lt;say #quot;hi#quot;#gt;<br/>alias"]`))
+		})
+	})
+
+	Describe("JSON", func() {
+		It("should render the nodes and edges", func() {
+			data, err := g.JSON()
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(data).To(ContainSubstring(`{
+      "id": "cache",
+      "kind": "undefined"
+    }`))
+
+			decoded := new(graph.Graph)
+			Expect(json.Unmarshal(data, decoded)).To(Succeed())
+			Expect(decoded).To(Equal(g))
+		})
+
+		It("should render empty graphs with empty lists", func() {
+			data, err := graph.New(goldi.NewTypeRegistry()).JSON()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(data).To(MatchJSON(`{"nodes": [], "edges": []}`))
+		})
+	})
+})
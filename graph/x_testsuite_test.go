@@ -0,0 +1,72 @@
+package graph_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func TestGraph(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Graph Test Suite")
+}
+
+// The types below act as mocks in the tests
+
+type Logger struct {
+	Level string
+}
+
+func NewLogger(level string) *Logger {
+	return &Logger{Level: level}
+}
+
+func (l *Logger) Named(name string) *Logger {
+	return l
+}
+
+type Middleware struct {
+	Name string
+}
+
+func NewMiddleware(name string) *Middleware {
+	return &Middleware{Name: name}
+}
+
+type Handler struct {
+	Logger      *Logger
+	Middlewares []*Middleware
+}
+
+func (h *Handler) ServeHTTP() {}
+
+type LoggerConfigurator struct{}
+
+func (c *LoggerConfigurator) Configure(l *Logger) error {
+	return nil
+}
+
+type Cache struct{}
+
+func NewHandler(logger *Logger, middlewares []*Middleware, cache *Cache) *Handler {
+	return &Handler{Logger: logger, Middlewares: middlewares}
+}
+
+func NewPrefixedLogger(inner *Logger, prefix string) *Logger {
+	return inner
+}
+
+type API struct {
+	Logger  *Logger `goldi:"@logger"`
+	Cache   *Cache  `goldi:"@?cache"`
+	Handler *Handler
+}
+
+func NewAPI(logger *Logger, handler *Handler) *API {
+	return &API{Logger: logger, Handler: handler}
+}
+
+func NewAuthenticator(logger *Logger, middleware *Middleware, cache *Cache) *Middleware {
+	return middleware
+}
@@ -8,6 +8,9 @@ type instanceType struct {
 
 	// The instance that this factory is going to return on each call to Generate
 	Instance interface{}
+
+	// Owned instances are closed by the container just like any generated instance (see Container.Close)
+	Owned bool
 }
 
 // NewInstanceType creates a new TypeFactory which will return the given instance on each call to Generate.
@@ -19,7 +22,18 @@ func NewInstanceType(instance interface{}) TypeFactory {
 		return newInvalidType(fmt.Errorf("refused to create a new InstanceType with instance being nil"))
 	}
 
-	return &instanceType{instance}
+	return &instanceType{Instance: instance}
+}
+
+// NewOwnedInstanceType behaves exactly like NewInstanceType but the container takes ownership of the instance.
+// This means that the instance will be closed when the container is closed (see Container.Close).
+func NewOwnedInstanceType(instance interface{}) TypeFactory {
+	t := NewInstanceType(instance)
+	if it, isInstanceType := t.(*instanceType); isInstanceType {
+		it.Owned = true
+	}
+
+	return t
 }
 
 func (t *instanceType) Generate(_ *ParameterResolver) (interface{}, error) {
@@ -36,6 +36,10 @@ var _ = Describe("instanceType", func() {
 		Expect(goldi.IsValid(goldi.NewInstanceType(nil))).To(BeFalse())
 	})
 
+	It("should return an invalid type if NewOwnedInstanceType is called with nil", func() {
+		Expect(goldi.IsValid(goldi.NewOwnedInstanceType(nil))).To(BeFalse())
+	})
+
 	Describe("Arguments()", func() {
 		It("should return an empty list", func() {
 			typeDef := goldi.NewInstanceType(NewFoo())
@@ -0,0 +1,207 @@
+package goldi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// Invalidate removes the cached instance of the given type and the cached instances of all types that directly or
+// transitively depend on it from the cache of this container. The next call to Get generates them again.
+// Use this to rebuild clients after credentials have been rotated or after a type has been registered again.
+//
+// The dependencies are discovered via the type references (e.g. "@db") and tagged references of TypeFactory.Arguments
+// and via the candidates of the autowired arguments of types from NewAutowiredType.
+// Invalidate returns the IDs of all evicted types in the reverse order of their creation which means that each type
+// comes before any of the types it depends on. The evicted instances are not closed (see InvalidateAndClose) and
+// scopes that have been created via NewScope are not affected. Evicted instances that have been started by
+// Container.Start keep running until Container.Stop is called.
+//
+// Types that are currently generated by other goroutines and that are affected by the invalidation are generated
+// again once they are done so no instance that depends on an evicted instance is cached afterwards.
+func (c *Container) Invalidate(typeID string) []string {
+	return c.invalidate(typeID).typeIDs
+}
+
+// InvalidateAndClose behaves like Invalidate but additionally stops and closes the evicted instances just like
+// Container.Stop and Container.Close would do. Afterwards the evicted types that had been started are generated
+// and started again (see Container.Start) so running services are replaced by new instances.
+// All errors are collected and returned together.
+func (c *Container) InvalidateAndClose(ctx context.Context, typeID string) error {
+	evicted := c.invalidate(typeID)
+	return errors.Join(c.release(ctx, evicted), c.restart(ctx, evicted))
+}
+
+// evictedTypes holds the types that have been removed from the cache by Container.evict.
+type evictedTypes struct {
+	typeIDs   []string           // the IDs of the evicted types in the reverse order of their creation
+	instances []interface{}      // the evicted instances in the same order as typeIDs
+	started   []*startedInstance // the evicted instances that have been started in the order of Container.Start
+}
+
+// invalidate evicts the given type and all its dependents.
+func (c *Container) invalidate(typeID string) evictedTypes {
+	return c.evict(c.dependentTypes(typeID))
+}
+
+// evict removes the given types from the cache. Started instances of these types stay in the list of started
+// instances so they are stopped by Container.Stop unless the caller stops them itself (see Container.release).
+//
+// Calls that are currently generating one of these types are marked as stale while the flight group is locked,
+// so they can not store an instance that still depends on an evicted instance (see Container.generateCached).
+func (c *Container) evict(affected map[string]bool) evictedTypes {
+	c.flights.mu.Lock()
+	defer c.flights.mu.Unlock()
+
+	for key, call := range c.flights.calls {
+		if key.container == c && affected[key.typeID] {
+			call.stale = true
+		}
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	var evicted evictedTypes
+	for i := len(c.creationOrder) - 1; i >= 0; i-- {
+		cachedTypeID := c.creationOrder[i]
+		if affected[cachedTypeID] == false {
+			continue
+		}
+
+		if instance, isCached := c.typeCache.LoadAndDelete(cachedTypeID); isCached {
+			evicted.typeIDs = append(evicted.typeIDs, cachedTypeID)
+			evicted.instances = append(evicted.instances, instance)
+		}
+	}
+
+	for _, s := range c.started {
+		if s.evicted == false && affected[s.typeID] {
+			s.evicted = true
+			evicted.started = append(evicted.started, s)
+		}
+	}
+
+	c.creationOrder = slices.DeleteFunc(c.creationOrder, func(id string) bool { return affected[id] })
+	return evicted
+}
+
+// release stops the evicted instances that have been started and closes all evicted instances that are owned by
+// the container (dependents first). All errors are collected and returned together.
+func (c *Container) release(ctx context.Context, evicted evictedTypes) error {
+	c.mu.Lock()
+	c.started = slices.DeleteFunc(c.started, func(s *startedInstance) bool { return slices.Contains(evicted.started, s) })
+	c.mu.Unlock()
+
+	errs := []error{c.stopInstances(ctx, evicted.started)}
+	for i, typeID := range evicted.typeIDs {
+		if err := ctx.Err(); err != nil {
+			errs = append(errs, fmt.Errorf("goldi: aborted closing invalidated types: %w", err))
+			break
+		}
+
+		if err := c.closeInstance(ctx, typeID, evicted.instances[i]); err != nil {
+			errs = append(errs, fmt.Errorf("goldi: error while closing type %q: %w", typeID, err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// restart generates the evicted types that had been started again and starts their new instances in the order in
+// which the old instances have been started. All errors are collected and returned together.
+func (c *Container) restart(ctx context.Context, evicted evictedTypes) error {
+	var errs []error
+	for _, s := range evicted.started {
+		instance, err := c.GetContext(ctx, s.typeID)
+		switch {
+		case err != nil:
+			errs = append(errs, fmt.Errorf("goldi: could not restart type %q: %w", s.typeID, err))
+		case c.isStarted(s.typeID):
+			// the new instance has already been started by a concurrent call to Container.Start
+		default:
+			if _, err := c.startInstance(ctx, s.typeID, instance); err != nil {
+				errs = append(errs, err)
+			}
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// dependentTypes returns the given type IDs and the IDs of all types that directly or transitively depend on them.
+func (c *Container) dependentTypes(typeIDs ...string) map[string]bool {
+	dependents := map[string][]string{}
+	for _, id := range c.visibleTypeIDs() {
+		_, generator, _ := c.lookup(id)
+		for _, dependency := range c.dependencies(generator) {
+			dependents[dependency] = append(dependents[dependency], id)
+		}
+	}
+
+	affected := map[string]bool{}
+	for _, typeID := range typeIDs {
+		affected[typeID] = true
+	}
+
+	queue := slices.Clone(typeIDs)
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+		for _, dependent := range dependents[current] {
+			if affected[dependent] == false {
+				affected[dependent] = true
+				queue = append(queue, dependent)
+			}
+		}
+	}
+
+	return affected
+}
+
+// visibleTypeIDs returns the IDs of all types of this container and its parents.
+func (c *Container) visibleTypeIDs() []string {
+	var typeIDs []string
+	seen := map[string]bool{}
+	for container := c; container != nil; container = container.parent {
+		for _, typeID := range container.typeIDs() {
+			if seen[typeID] == false {
+				seen[typeID] = true
+				typeIDs = append(typeIDs, typeID)
+			}
+		}
+	}
+
+	return typeIDs
+}
+
+// dependencies returns the IDs of all types that are referenced in the arguments of the given type factory and of
+// all types that are candidates for its autowired arguments (see NewAutowiredType).
+func (c *Container) dependencies(generator TypeFactory) []string {
+	var typeIDs []string
+	if autowired, isAutowired := findEmbedded[*autowiredType](generator); isAutowired {
+		for _, argumentType := range autowired.autowiredArgumentTypes() {
+			typeIDs = append(typeIDs, c.autowireCandidates(argumentType)...)
+		}
+	}
+
+	for _, argument := range generator.Arguments() {
+		stringArgument, isString := argument.(string)
+		switch {
+		case isString == false:
+		case IsTypeReference(stringArgument):
+			if referencedTypeID := NewTypeID(stringArgument).ID; referencedTypeID != InnerTypeID {
+				typeIDs = append(typeIDs, referencedTypeID)
+			}
+		case IsTaggedReference(stringArgument):
+			tagName := strings.TrimSpace(stringArgument[len(taggedReferencePrefix):])
+			for _, tagged := range c.FindTaggedTypes(tagName) {
+				typeIDs = append(typeIDs, tagged.TypeID)
+			}
+		}
+	}
+
+	return typeIDs
+}
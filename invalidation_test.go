@@ -0,0 +1,234 @@
+package goldi_test
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/tarokamikaze/goldi"
+)
+
+var _ = Describe("Container invalidation", func() {
+	var (
+		registry  goldi.TypeRegistry
+		container *goldi.Container
+		log       *ShutdownLog
+	)
+
+	BeforeEach(func() {
+		registry = goldi.NewTypeRegistry()
+		container = goldi.NewContainer(registry, map[string]interface{}{})
+		log = new(ShutdownLog)
+		container.InjectInstance("log", log)
+
+		registry.RegisterType("credentials", &Closable{}, "credentials", "@log")
+		registry.RegisterType("client", &Closable{}, "client", "@log", "@credentials")
+		registry.RegisterType("service", &Closable{}, "service", "@log", "@client")
+		registry.RegisterType("unrelated", &Closable{}, "unrelated", "@log")
+	})
+
+	Describe("Invalidate", func() {
+		It("should evict the type and all types that depend on it", func() {
+			container.MustGet("service")
+			container.MustGet("unrelated")
+
+			Expect(container.Invalidate("credentials")).To(Equal([]string{"service", "client", "credentials"}))
+			Expect(container.CollectCachedTypeIDs()).To(ConsistOf("log", "unrelated"))
+			Expect(log.Events).To(BeEmpty())
+		})
+
+		It("should rebuild the evicted types on the next request", func() {
+			service := container.MustGet("service").(*Closable)
+			unrelated := container.MustGet("unrelated")
+
+			container.Invalidate("credentials")
+
+			rebuilt := container.MustGet("service").(*Closable)
+			Expect(rebuilt).NotTo(BeIdenticalTo(service))
+			Expect(rebuilt.Dependency).NotTo(BeIdenticalTo(service.Dependency))
+			Expect(container.MustGet("unrelated")).To(BeIdenticalTo(unrelated))
+		})
+
+		It("should pick up a type that has been registered again", func() {
+			container.MustGet("service")
+
+			container.Register("credentials", goldi.NewStructType(&Closable{}, "rotated credentials", "@log"))
+			container.Invalidate("credentials")
+
+			service := container.MustGet("service").(*Closable)
+			Expect(service.Dependency.Dependency.Name).To(Equal("rotated credentials"))
+		})
+
+		It("should only evict the dependents of the given type", func() {
+			container.MustGet("service")
+			Expect(container.Invalidate("client")).To(Equal([]string{"service", "client"}))
+			Expect(container.CollectCachedTypeIDs()).To(ConsistOf("log", "credentials"))
+		})
+
+		It("should find dependents via types that are not cached", func() {
+			registry.Register("client", goldi.NewScopedType(goldi.NewStructType(&Closable{}, "client", "@log", "@credentials"), goldi.Prototype))
+			container.MustGet("service")
+
+			Expect(container.Invalidate("credentials")).To(Equal([]string{"service", "credentials"}))
+		})
+
+		It("should find dependents via tagged references", func() {
+			registry.Register("credentials", goldi.NewTaggedType(goldi.NewStructType(&Closable{}, "credentials", "@log"), goldi.Tag{Name: "secret"}))
+			registry.RegisterType("vault", NewVault, "!tagged secret")
+			container.MustGet("vault")
+
+			Expect(container.Invalidate("credentials")).To(Equal([]string{"vault", "credentials"}))
+		})
+
+		It("should find dependents of decorators", func() {
+			registry.RegisterType("token", &Closable{}, "token", "@log")
+			registry.Register("authenticated_client", goldi.NewDecoratorType(goldi.NewType(NewTokenClient, "@.inner", "@token"), "client", 0))
+			container.MustGet("service")
+
+			Expect(container.Invalidate("token")).To(Equal([]string{"service", "client", "token"}))
+		})
+
+		It("should return nothing if the type has not been cached", func() {
+			Expect(container.Invalidate("service")).To(BeEmpty())
+			Expect(container.Invalidate("unknown")).To(BeEmpty())
+		})
+
+		It("should not affect the instances of the parent container", func() {
+			credentials := container.MustGet("credentials")
+			scope := container.NewScope()
+
+			Expect(scope.Invalidate("credentials")).To(BeEmpty())
+			Expect(container.MustGet("credentials")).To(BeIdenticalTo(credentials))
+		})
+
+		It("should stop the evicted instances that have been started on the next Stop", func() {
+			registry.RegisterType("db", &Server{}, "db", "@log")
+			registry.RegisterType("api", &Server{}, "api", "@log", "@db")
+			container.MustGet("api")
+			Expect(container.Start(context.Background())).To(Succeed())
+
+			Expect(container.Invalidate("db")).To(Equal([]string{"api", "db"}))
+			container.MustGet("api")
+			Expect(container.Start(context.Background())).To(Succeed())
+			Expect(log.Events).To(Equal([]string{"start db", "start api", "start db", "start api"}))
+
+			Expect(container.Stop(context.Background())).To(Succeed())
+			Expect(log.Events[4:]).To(Equal([]string{"stop api", "stop db", "stop api", "stop db"}))
+		})
+
+		It("should generate types again that have been invalidated while they were generated", func() {
+			entered, release := make(chan struct{}), make(chan struct{})
+			var calls atomic.Int32
+			registry.Register("slow", goldi.NewType(func(credentials *Closable) *Closable {
+				if calls.Add(1) == 1 {
+					close(entered)
+					<-release
+				}
+				return &Closable{Name: "slow", Log: log, Dependency: credentials}
+			}, "@credentials"))
+
+			result := make(chan *Closable)
+			go func() {
+				defer GinkgoRecover()
+				result <- container.MustGet("slow").(*Closable)
+			}()
+
+			<-entered
+			oldCredentials := container.MustGet("credentials")
+			Expect(container.Invalidate("credentials")).To(Equal([]string{"credentials"}))
+			close(release)
+
+			slow := <-result
+			Expect(slow.Dependency).NotTo(BeIdenticalTo(oldCredentials))
+			Expect(slow.Dependency).To(BeIdenticalTo(container.MustGet("credentials")))
+			Expect(container.MustGet("slow")).To(BeIdenticalTo(slow))
+			Expect(calls.Load()).To(BeEquivalentTo(2))
+			Expect(log.Events).To(Equal([]string{"close slow"}))
+		})
+	})
+
+	Describe("InvalidateAndClose", func() {
+		It("should stop the started instances before closing them and start the new instances", func() {
+			registry.RegisterType("db", &Server{}, "db", "@log")
+			registry.RegisterType("api", &Server{}, "api", "@log", "@db")
+			container.MustGet("api")
+			Expect(container.Start(context.Background())).To(Succeed())
+
+			Expect(container.InvalidateAndClose(context.Background(), "db")).To(Succeed())
+			Expect(log.Events).To(Equal([]string{
+				"start db", "start api",
+				"stop api", "stop db", "close api", "close db",
+				"start db", "start api",
+			}))
+
+			Expect(container.Stop(context.Background())).To(Succeed())
+			Expect(container.Close(context.Background())).To(Succeed())
+			Expect(log.Events[8:]).To(Equal([]string{"stop api", "stop db", "close api", "close db"}))
+		})
+
+		It("should close the evicted instances with the dependents first", func() {
+			container.MustGet("service")
+			container.MustGet("unrelated")
+
+			Expect(container.InvalidateAndClose(context.Background(), "credentials")).To(Succeed())
+			Expect(log.Events).To(Equal([]string{"close service", "close client", "close credentials"}))
+		})
+
+		It("should evict and close the types that are autowired with an evicted type", func() {
+			registry.RegisterType("db", &Server{}, "db", "@log")
+			registry.Register("users", goldi.NewAutowiredType(NewUsers))
+			users := container.MustGet("users").(*Users)
+
+			Expect(container.InvalidateAndClose(context.Background(), "db")).To(Succeed())
+			Expect(log.Events).To(Equal([]string{"close db"}))
+
+			rebuilt := container.MustGet("users").(*Users)
+			Expect(rebuilt).NotTo(BeIdenticalTo(users))
+			Expect(rebuilt.DB).To(BeIdenticalTo(container.MustGet("db")))
+		})
+
+		It("should not close injected instances", func() {
+			container.MustGet("service")
+			Expect(container.InvalidateAndClose(context.Background(), "log")).To(Succeed())
+			Expect(log.Events).To(Equal([]string{"close service", "close client", "close credentials"}))
+		})
+
+		It("should return all errors", func() {
+			container.InjectOwnedInstance("credentials", &Closable{Name: "credentials", Log: log, Err: errors.New("oops")})
+			container.MustGet("service")
+
+			err := container.InvalidateAndClose(context.Background(), "credentials")
+			Expect(err).To(MatchError(`goldi: error while closing type "credentials": oops`))
+			Expect(log.Events).To(Equal([]string{"close service", "close client", "close credentials"}))
+		})
+
+		It("should not close the instances on the next Close again", func() {
+			container.MustGet("service")
+			Expect(container.InvalidateAndClose(context.Background(), "client")).To(Succeed())
+
+			Expect(container.Close(context.Background())).To(Succeed())
+			Expect(log.Events).To(Equal([]string{"close service", "close client", "close credentials"}))
+		})
+	})
+})
+
+// NewVault depends on all closables that have been tagged with a certain tag
+func NewVault(secrets []*Closable) *Closable {
+	return &Closable{Name: "vault", Log: secrets[0].Log}
+}
+
+// NewTokenClient decorates a client
+func NewTokenClient(inner *Closable, token *Closable) *Closable {
+	return inner
+}
+
+// Users is generated via NewAutowiredType and depends on a *Server
+type Users struct {
+	DB *Server
+}
+
+func NewUsers(db *Server) *Users {
+	return &Users{DB: db}
+}
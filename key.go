@@ -0,0 +1,87 @@
+package goldi
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+)
+
+// A Key identifies a registered type together with the Go type of the instances it generates.
+// Keys are usually declared once as package level variables and then used for registration and retrieval:
+//
+//	var LoggerKey = goldi.NewKey[Logger]("logger")
+//
+//	goldi.Provide(registry, LoggerKey, goldi.NewType(NewSimpleLogger))
+//	logger, err := goldi.Resolve(container, LoggerKey) // logger is of type Logger
+//
+// A Key is just a typed type ID so types that have been registered with a Key can still be referenced via "@logger"
+// and retrieved using Container.Get.
+type Key[T any] struct {
+	id string
+}
+
+// NewKey creates a Key for the given type ID.
+func NewKey[T any](typeID string) Key[T] {
+	return Key[T]{id: typeID}
+}
+
+// ID returns the type ID of the key.
+func (k Key[T]) ID() string {
+	return k.id
+}
+
+// Type returns the Go type of the instances that are retrieved with this key.
+func (k Key[T]) Type() reflect.Type {
+	return reflect.TypeFor[T]()
+}
+
+// String returns the type ID of the key.
+func (k Key[T]) String() string {
+	return k.id
+}
+
+// Provide registers the factory under the type ID of the given key.
+//
+// Provide panics if the type that is generated by the factory is not assignable to the type of the key. This way
+// registering a factory with the wrong key fails when the types are registered and not when they are retrieved.
+// If the generated type can not be determined without generating an instance (e.g. for aliases or proxy types) the
+// factory is registered as is and Resolve checks the type of the generated instance instead.
+func Provide[T any](registry TypeRegistrar, key Key[T], factory TypeFactory) {
+	if generatedType := GeneratedTypeOf(factory); generatedType != nil && generatedType.AssignableTo(key.Type()) == false {
+		panic(fmt.Errorf("goldi: could not register type %q: the factory generates %v which is not assignable to %v", key.id, generatedType, key.Type()))
+	}
+
+	registry.Register(key.id, factory)
+}
+
+// Resolve retrieves the type with the given key from the container.
+// Resolve behaves exactly like Container.Get but returns the type of the key instead of interface{}.
+func Resolve[T any](c *Container, key Key[T]) (T, error) {
+	return ResolveContext(context.Background(), c, key)
+}
+
+// ResolveContext behaves like Resolve but passes the given context to the type factories (see Container.GetContext).
+func ResolveContext[T any](ctx context.Context, c *Container, key Key[T]) (T, error) {
+	var zero T
+	instance, err := c.GetContext(ctx, key.id)
+	if err != nil {
+		return zero, err
+	}
+
+	typed, isAssignable := instance.(T)
+	if isAssignable == false && instance != nil {
+		return zero, fmt.Errorf("goldi: type %q generated %T which is not assignable to %v", key.id, instance, key.Type())
+	}
+
+	return typed, nil
+}
+
+// MustResolve behaves exactly like Resolve but will panic instead of returning an error.
+func MustResolve[T any](c *Container, key Key[T]) T {
+	instance, err := Resolve(c, key)
+	if err != nil {
+		panic(err)
+	}
+
+	return instance
+}
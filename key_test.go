@@ -0,0 +1,109 @@
+package goldi_test
+
+import (
+	"fmt"
+	"reflect"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/tarokamikaze/goldi"
+)
+
+var (
+	LoggerKey = goldi.NewKey[Logger]("logger")
+	MockKey   = goldi.NewKey[*MockType]("mock")
+)
+
+func ExampleResolve() {
+	registry := goldi.NewTypeRegistry()
+	goldi.Provide(registry, LoggerKey, goldi.NewStructType(SimpleLogger{}, "typed"))
+
+	container := goldi.NewContainer(registry, map[string]interface{}{})
+	logger := goldi.MustResolve(container, LoggerKey) // logger is a Logger so no type assertion is necessary
+	logger.Log("Hello World")
+	// Output:
+	// LOG: Hello World
+}
+
+var _ = Describe("Key", func() {
+	var (
+		registry  goldi.TypeRegistry
+		container *goldi.Container
+	)
+
+	BeforeEach(func() {
+		registry = goldi.NewTypeRegistry()
+		container = goldi.NewContainer(registry, map[string]interface{}{})
+	})
+
+	It("should carry its type ID and type", func() {
+		Expect(LoggerKey.ID()).To(Equal("logger"))
+		Expect(LoggerKey.String()).To(Equal("logger"))
+		Expect(LoggerKey.Type()).To(Equal(reflect.TypeFor[Logger]()))
+	})
+
+	Describe("Provide", func() {
+		It("should register the factory under the type ID of the key", func() {
+			goldi.Provide(registry, MockKey, goldi.NewType(NewMockType))
+			Expect(container.MustGet("mock")).To(BeAssignableToTypeOf(&MockType{}))
+		})
+
+		It("should accept factories that generate an implementation of an interface key", func() {
+			goldi.Provide(registry, LoggerKey, goldi.NewStructType(SimpleLogger{}))
+			Expect(registry).To(HaveKey("logger"))
+		})
+
+		It("should check the generated type of wrapped factories", func() {
+			Expect(func() {
+				goldi.Provide(registry, MockKey, goldi.NewScopedType(goldi.NewType(NewFoo), goldi.Prototype))
+			}).To(PanicWith(MatchError(`goldi: could not register type "mock": the factory generates *goldi_test.Foo which is not assignable to *goldi_test.MockType`)))
+		})
+
+		It("should panic if the factory generates a type that is not assignable to the type of the key", func() {
+			Expect(func() { goldi.Provide(registry, LoggerKey, goldi.NewType(NewMockType)) }).To(Panic())
+			Expect(registry).NotTo(HaveKey("logger"))
+		})
+
+		It("should check the generated type of autowired factories", func() {
+			Expect(func() { goldi.Provide(registry, LoggerKey, goldi.NewAutowiredType(NewMockType)) }).To(Panic())
+		})
+
+		It("should register factories whose generated type is not known up front", func() {
+			goldi.Provide(registry, LoggerKey, goldi.NewAliasType("simple_logger"))
+			Expect(registry).To(HaveKey("logger"))
+		})
+	})
+
+	Describe("Resolve", func() {
+		It("should return the typed instance", func() {
+			goldi.Provide(registry, MockKey, goldi.NewType(NewMockType))
+
+			mock, err := goldi.Resolve(container, MockKey)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(mock).To(BeIdenticalTo(container.MustGet("mock")))
+		})
+
+		It("should resolve types that have been registered with the string API", func() {
+			registry.Register("mock", goldi.NewType(NewMockType))
+			Expect(goldi.MustResolve(container, MockKey)).NotTo(BeNil())
+		})
+
+		It("should return an error if the type has not been defined", func() {
+			_, err := goldi.Resolve(container, MockKey)
+			Expect(err).To(BeAssignableToTypeOf(goldi.UnknownTypeReferenceError{}))
+			Expect(err).To(MatchError("no such type has been defined"))
+		})
+
+		It("should return an error if the generated instance is not assignable to the type of the key", func() {
+			registry.Register("foo", goldi.NewType(NewFoo))
+			goldi.Provide(registry, MockKey, goldi.NewAliasType("foo"))
+
+			_, err := goldi.Resolve(container, MockKey)
+			Expect(err).To(MatchError(fmt.Sprintf(`goldi: type "mock" generated %T which is not assignable to *goldi_test.MockType`, &Foo{})))
+		})
+
+		It("should panic in MustResolve if the type can not be resolved", func() {
+			Expect(func() { goldi.MustResolve(container, MockKey) }).To(Panic())
+		})
+	})
+})
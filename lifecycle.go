@@ -0,0 +1,261 @@
+package goldi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"slices"
+)
+
+var (
+	contextType = reflect.TypeOf((*context.Context)(nil)).Elem()
+	errorType   = reflect.TypeOf((*error)(nil)).Elem()
+)
+
+// A Starter is a type that needs to be started before it can be used (e.g. a server or a background worker).
+// The container calls Start on all cached instances that implement this interface when it is started.
+type Starter interface {
+	Start(ctx context.Context) error
+}
+
+// A Stopper is a type that needs to be stopped when the application shuts down.
+// The container calls Stop on all started instances that implement this interface when it is stopped.
+type Stopper interface {
+	Stop(ctx context.Context) error
+}
+
+// A startedInstance is an instance that has been started by Container.Start and that has to be stopped by Stop.
+type startedInstance struct {
+	typeID   string
+	instance interface{}
+
+	// evicted is set if the instance has been removed from the cache by Container.Invalidate. The instance keeps
+	// running until Stop is called while the type can be generated and started again.
+	evicted bool
+}
+
+// contextCloser is implemented by all types that need a context to shut down.
+type contextCloser interface {
+	Close(ctx context.Context) error
+}
+
+// Close releases all instances that have been cached by this container.
+//
+// The cached instances are closed in the reverse order of their creation so each instance is closed before any of
+// the instances it depends on. An instance is closed by calling the shutdown method that was configured via
+// NewShutdownType or its Close(context.Context) error or io.Closer implementation (in that order).
+// Instances that have been added using InjectInstance are not closed because the container does not own them
+// (use InjectOwnedInstance instead). Prototypes are never cached so it is up to the caller to close them.
+//
+// Instances that have been cached by a parent container are not affected so closing a scope releases only the
+// instances of that scope (see Container.NewScope). All errors are collected and returned together.
+// If the context is done before all instances have been closed Close stops and returns the context error.
+// The instances that have not been closed yet stay in the cache so Close can be called again.
+func (c *Container) Close(ctx context.Context) error {
+	c.mu.Lock()
+	creationOrder := c.creationOrder
+	c.creationOrder = nil
+	c.mu.Unlock()
+
+	var errs []error
+	for i := len(creationOrder) - 1; i >= 0; i-- {
+		typeID := creationOrder[i]
+		if err := ctx.Err(); err != nil {
+			c.mu.Lock()
+			c.creationOrder = append(slices.Clone(creationOrder[:i+1]), c.creationOrder...)
+			c.mu.Unlock()
+
+			errs = append(errs, fmt.Errorf("goldi: aborted closing the container: %w", err))
+			return errors.Join(errs...)
+		}
+
+		instance, isCached := c.typeCache.LoadAndDelete(typeID)
+		if isCached == false {
+			continue
+		}
+
+		c.mu.Lock()
+		c.started = slices.DeleteFunc(c.started, func(s *startedInstance) bool { return s.typeID == typeID && s.evicted == false })
+		c.mu.Unlock()
+
+		if err := c.closeInstance(ctx, typeID, instance); err != nil {
+			errs = append(errs, fmt.Errorf("goldi: error while closing type %q: %w", typeID, err))
+		}
+	}
+
+	c.typeCache.Clear()
+	return errors.Join(errs...)
+}
+
+func (c *Container) closeInstance(ctx context.Context, typeID string, instance interface{}) error {
+	generator, isOwned := c.ownedFactory(typeID)
+	if isOwned == false {
+		return nil
+	}
+
+	if shutdown, hasShutdownMethod := findEmbedded[*shutdownType](generator); hasShutdownMethod {
+		return shutdown.shutdown(ctx, instance)
+	}
+
+	switch closer := instance.(type) {
+	case contextCloser:
+		return closer.Close(ctx)
+	case io.Closer:
+		return closer.Close()
+	default:
+		return nil
+	}
+}
+
+// ownedFactory returns the type factory of the given type if the container owns the instances it generates.
+// The container owns all instances except the ones that have been injected via InjectInstance.
+func (c *Container) ownedFactory(typeID string) (TypeFactory, bool) {
+	_, generator, isDefined := c.lookup(typeID)
+	if isDefined == false {
+		return nil, false
+	}
+
+	if injected, isInstance := findEmbedded[*instanceType](generator); isInstance && injected.Owned == false {
+		return nil, false
+	}
+
+	return generator, true
+}
+
+// Start generates all eager types (see NewEagerType) and then calls Start on all cached instances that implement
+// the Starter interface. The instances are started in dependency order which means that each instance is started
+// after all instances it depends on. Just like in Container.Close, instances added via InjectInstance are skipped.
+//
+// If any type can not be generated or any instance fails to start, all instances that have been started by this
+// call are stopped again in reverse order and the error is returned. Instances that have been started by an earlier
+// call keep running. Start also aborts if the given context is done.
+// Use the context to define a deadline for the whole startup.
+func (c *Container) Start(ctx context.Context) error {
+	var started []*startedInstance
+	for _, typeID := range c.eagerTypeIDs() {
+		if err := ctx.Err(); err != nil {
+			return c.rollbackStart(ctx, started, fmt.Errorf("goldi: aborted starting the container: %w", err))
+		}
+
+		if _, err := c.GetContext(ctx, typeID); err != nil {
+			return c.rollbackStart(ctx, started, fmt.Errorf("goldi: could not generate eager type %q: %w", typeID, err))
+		}
+	}
+
+	c.mu.Lock()
+	creationOrder := slices.Clone(c.creationOrder)
+	c.mu.Unlock()
+
+	for _, typeID := range creationOrder {
+		instance, isCached := c.typeCache.Load(typeID)
+		if isCached == false || c.isStarted(typeID) {
+			continue
+		}
+
+		if _, isOwned := c.ownedFactory(typeID); isOwned == false {
+			continue
+		}
+
+		if err := ctx.Err(); err != nil {
+			return c.rollbackStart(ctx, started, fmt.Errorf("goldi: aborted starting the container: %w", err))
+		}
+
+		s, err := c.startInstance(ctx, typeID, instance)
+		if err != nil {
+			return c.rollbackStart(ctx, started, err)
+		}
+
+		if s != nil {
+			started = append(started, s)
+		}
+	}
+
+	return nil
+}
+
+// startInstance calls Start on the given instance if it implements the Starter interface and remembers the instance
+// so it is stopped by Container.Stop. Instances that only implement the Stopper interface are considered to be
+// started as well. It returns nil if the instance implements neither of these interfaces.
+func (c *Container) startInstance(ctx context.Context, typeID string, instance interface{}) (*startedInstance, error) {
+	starter, isStarter := instance.(Starter)
+	if isStarter {
+		if err := starter.Start(ctx); err != nil {
+			return nil, fmt.Errorf("goldi: error while starting type %q: %w", typeID, err)
+		}
+	}
+
+	if _, isStopper := instance.(Stopper); isStarter == false && isStopper == false {
+		return nil, nil
+	}
+
+	s := &startedInstance{typeID: typeID, instance: instance}
+	c.mu.Lock()
+	c.started = append(c.started, s)
+	c.mu.Unlock()
+	return s, nil
+}
+
+// Stop calls Stop on all instances that have been started by Container.Start and that implement the Stopper
+// interface. The instances are stopped in the reverse order in which they have been started.
+// Instances that have been removed from the cache by Container.Invalidate are stopped as well.
+// All errors are collected and returned together.
+func (c *Container) Stop(ctx context.Context) error {
+	c.mu.Lock()
+	started := c.started
+	c.started = nil
+	c.mu.Unlock()
+
+	return c.stopInstances(ctx, started)
+}
+
+// stopInstances calls Stop on the given started instances in reverse order.
+func (c *Container) stopInstances(ctx context.Context, started []*startedInstance) error {
+	var errs []error
+	for i := len(started) - 1; i >= 0; i-- {
+		if stopper, ok := started[i].instance.(Stopper); ok {
+			if err := stopper.Stop(ctx); err != nil {
+				errs = append(errs, fmt.Errorf("goldi: error while stopping type %q: %w", started[i].typeID, err))
+			}
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// rollbackStart stops the given instances that have been started by the failed call to Start.
+// The instances are stopped even if the context of the failed start is already done.
+func (c *Container) rollbackStart(ctx context.Context, started []*startedInstance, err error) error {
+	c.mu.Lock()
+	c.started = slices.DeleteFunc(c.started, func(s *startedInstance) bool { return slices.Contains(started, s) })
+	c.mu.Unlock()
+
+	if stopErr := c.stopInstances(context.WithoutCancel(ctx), started); stopErr != nil {
+		return errors.Join(err, stopErr)
+	}
+
+	return err
+}
+
+// isStarted returns whether the cached instance of the given type has been started.
+func (c *Container) isStarted(typeID string) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return slices.ContainsFunc(c.started, func(s *startedInstance) bool { return s.typeID == typeID && s.evicted == false })
+}
+
+// eagerTypeIDs returns the alphabetically sorted IDs of all eager types of this container.
+func (c *Container) eagerTypeIDs() []string {
+	var typeIDs []string
+	c.readTypes(func(types TypeRegistry) {
+		for typeID, factory := range types {
+			if IsEager(factory) {
+				typeIDs = append(typeIDs, typeID)
+			}
+		}
+	})
+
+	slices.Sort(typeIDs)
+	return typeIDs
+}
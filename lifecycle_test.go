@@ -0,0 +1,299 @@
+package goldi_test
+
+import (
+	"context"
+	"errors"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/tarokamikaze/goldi"
+)
+
+// ShutdownLog records the order in which the lifecycle methods of the test components have been called
+type ShutdownLog struct {
+	Events []string
+}
+
+func (l *ShutdownLog) Add(event string) {
+	l.Events = append(l.Events, event)
+}
+
+// Closable implements io.Closer
+type Closable struct {
+	Name       string
+	Log        *ShutdownLog
+	Dependency *Closable
+	Err        error
+}
+
+func (c *Closable) Close() error {
+	c.Log.Add("close " + c.Name)
+	return c.Err
+}
+
+// ContextClosable implements Close(context.Context) error
+type ContextClosable struct {
+	Log *ShutdownLog
+	Ctx context.Context
+}
+
+func (c *ContextClosable) Close(ctx context.Context) error {
+	c.Ctx = ctx
+	c.Log.Add("close context closable")
+	return nil
+}
+
+// Stoppable does neither implement io.Closer nor Close(context.Context) error
+type Stoppable struct {
+	Log *ShutdownLog
+}
+
+func (s *Stoppable) Shutdown() {
+	s.Log.Add("shutdown stoppable")
+}
+
+// Component implements the Starter and Stopper interfaces
+type Component struct {
+	Name       string
+	Log        *ShutdownLog
+	Dependency *Component
+	StartErr   error
+}
+
+func (c *Component) Start(ctx context.Context) error {
+	if c.StartErr != nil {
+		return c.StartErr
+	}
+
+	c.Log.Add("start " + c.Name)
+	return ctx.Err()
+}
+
+func (c *Component) Stop(ctx context.Context) error {
+	c.Log.Add("stop " + c.Name)
+	return nil
+}
+
+// Server implements the Starter and Stopper interfaces as well as io.Closer
+type Server struct {
+	Name       string
+	Log        *ShutdownLog
+	Dependency *Server
+}
+
+func (s *Server) Start(ctx context.Context) error {
+	s.Log.Add("start " + s.Name)
+	return nil
+}
+
+func (s *Server) Stop(ctx context.Context) error {
+	s.Log.Add("stop " + s.Name)
+	return nil
+}
+
+func (s *Server) Close() error {
+	s.Log.Add("close " + s.Name)
+	return nil
+}
+
+var _ = Describe("Container lifecycle", func() {
+	var (
+		registry  goldi.TypeRegistry
+		container *goldi.Container
+		log       *ShutdownLog
+		ctx       context.Context
+	)
+
+	BeforeEach(func() {
+		registry = goldi.NewTypeRegistry()
+		container = goldi.NewContainer(registry, map[string]interface{}{})
+		log = new(ShutdownLog)
+		ctx = context.Background()
+		container.InjectInstance("log", log)
+	})
+
+	Describe("Close", func() {
+		It("should close the cached instances in reverse dependency order", func() {
+			registry.RegisterType("db", &Closable{}, "db", "@log")
+			registry.RegisterType("repository", &Closable{}, "repository", "@log", "@db")
+			registry.RegisterType("service", &Closable{}, "service", "@log", "@repository")
+			registry.RegisterType("unused", &Closable{}, "unused", "@log")
+
+			container.MustGet("service")
+			Expect(container.Close(ctx)).To(Succeed())
+			Expect(log.Events).To(Equal([]string{"close service", "close repository", "close db"}))
+		})
+
+		It("should remove all instances from the cache", func() {
+			registry.RegisterType("db", &Closable{}, "db", "@log")
+			first := container.MustGet("db")
+
+			Expect(container.Close(ctx)).To(Succeed())
+			Expect(container.CollectCachedTypeIDs()).To(BeEmpty())
+			Expect(container.MustGet("db") == first).To(BeFalse())
+		})
+
+		It("should pass the context to instances that implement Close(context.Context) error", func() {
+			registry.RegisterType("closable", &ContextClosable{}, "@log")
+			closable := container.MustGet("closable").(*ContextClosable)
+
+			Expect(container.Close(ctx)).To(Succeed())
+			Expect(log.Events).To(Equal([]string{"close context closable"}))
+			Expect(closable.Ctx).To(BeIdenticalTo(ctx))
+		})
+
+		It("should call the configured shutdown method", func() {
+			registry.Register("stoppable", goldi.NewShutdownType(goldi.NewStructType(&Stoppable{}, "@log"), "Shutdown"))
+			container.MustGet("stoppable")
+
+			Expect(container.Close(ctx)).To(Succeed())
+			Expect(log.Events).To(Equal([]string{"shutdown stoppable"}))
+		})
+
+		It("should not close injected instances", func() {
+			container.InjectInstance("injected", &Closable{Name: "injected", Log: log})
+			container.MustGet("injected")
+
+			Expect(container.Close(ctx)).To(Succeed())
+			Expect(log.Events).To(BeEmpty())
+		})
+
+		It("should close owned injected instances", func() {
+			container.InjectOwnedInstance("injected", &Closable{Name: "injected", Log: log})
+			container.MustGet("injected")
+
+			Expect(container.Close(ctx)).To(Succeed())
+			Expect(log.Events).To(Equal([]string{"close injected"}))
+		})
+
+		It("should close all instances and return all errors", func() {
+			registry.RegisterType("a", &Closable{}, "a", "@log", "@?none", errors.New("error A"))
+			registry.RegisterType("b", &Closable{}, "b", "@log")
+			registry.RegisterType("c", &Closable{}, "c", "@log", "@?none", errors.New("error C"))
+			container.MustGet("a")
+			container.MustGet("b")
+			container.MustGet("c")
+
+			err := container.Close(ctx)
+			Expect(log.Events).To(Equal([]string{"close c", "close b", "close a"}))
+			Expect(err).To(MatchError(ContainSubstring(`goldi: error while closing type "c": error C`)))
+			Expect(err).To(MatchError(ContainSubstring(`goldi: error while closing type "a": error A`)))
+		})
+
+		It("should stop closing instances when the context is done", func() {
+			registry.RegisterType("db", &Closable{}, "db", "@log")
+			container.MustGet("db")
+
+			canceled, cancel := context.WithCancel(ctx)
+			cancel()
+
+			err := container.Close(canceled)
+			Expect(errors.Is(err, context.Canceled)).To(BeTrue())
+			Expect(log.Events).To(BeEmpty())
+		})
+
+		It("should keep the instances that have not been closed when the context is done", func() {
+			registry.RegisterType("db", &Closable{}, "db", "@log")
+			db := container.MustGet("db")
+
+			canceled, cancel := context.WithCancel(ctx)
+			cancel()
+
+			Expect(container.Close(canceled)).NotTo(Succeed())
+			Expect(container.MustGet("db")).To(BeIdenticalTo(db))
+
+			Expect(container.Close(ctx)).To(Succeed())
+			Expect(log.Events).To(Equal([]string{"close db"}))
+		})
+
+		It("should only close the instances of a scope", func() {
+			registry.RegisterType("db", &Closable{}, "db", "@log")
+			registry.Register("request", goldi.NewScopedType(goldi.NewStructType(&Closable{}, "request", "@log", "@db"), goldi.Scoped))
+
+			scope := container.NewScope()
+			scope.MustGet("request")
+
+			Expect(scope.Close(ctx)).To(Succeed())
+			Expect(log.Events).To(Equal([]string{"close request"}))
+			Expect(container.CollectCachedTypeIDs()).To(ContainElement("db"))
+		})
+	})
+
+	Describe("Start", func() {
+		BeforeEach(func() {
+			registry.RegisterType("db", &Component{}, "db", "@log")
+			registry.RegisterType("cache", &Component{}, "cache", "@log", "@db")
+			registry.Register("server", goldi.NewEagerType(goldi.NewStructType(&Component{}, "server", "@log", "@cache")))
+			registry.RegisterType("lazy", &Component{}, "lazy", "@log")
+		})
+
+		It("should generate the eager types and start them in dependency order", func() {
+			Expect(container.Start(ctx)).To(Succeed())
+			Expect(log.Events).To(Equal([]string{"start db", "start cache", "start server"}))
+			Expect(container.CollectCachedTypeIDs()).NotTo(ContainElement("lazy"))
+		})
+
+		It("should not start instances twice", func() {
+			Expect(container.Start(ctx)).To(Succeed())
+			container.MustGet("lazy")
+			Expect(container.Start(ctx)).To(Succeed())
+			Expect(log.Events).To(Equal([]string{"start db", "start cache", "start server", "start lazy"}))
+		})
+
+		It("should stop all started instances if an instance fails to start", func() {
+			registry.Register("worker", goldi.NewEagerType(goldi.NewStructType(&Component{}, "worker", "@log", "@server", errors.New("oops"))))
+
+			err := container.Start(ctx)
+			Expect(err).To(MatchError(`goldi: error while starting type "worker": oops`))
+			Expect(log.Events).To(Equal([]string{"start db", "start cache", "start server", "stop server", "stop cache", "stop db"}))
+		})
+
+		It("should only stop the instances that have been started by the failed call", func() {
+			Expect(container.Start(ctx)).To(Succeed())
+
+			container.MustGet("lazy")
+			registry.Register("worker", goldi.NewEagerType(goldi.NewStructType(&Component{}, "worker", "@log", "@server", errors.New("oops"))))
+			Expect(container.Start(ctx)).NotTo(Succeed())
+			Expect(log.Events).To(Equal([]string{"start db", "start cache", "start server", "start lazy", "stop lazy"}))
+
+			Expect(container.Stop(ctx)).To(Succeed())
+			Expect(log.Events[5:]).To(Equal([]string{"stop server", "stop cache", "stop db"}))
+		})
+
+		It("should return an error if an eager type can not be generated", func() {
+			registry.Register("worker", goldi.NewEagerType(goldi.NewType(NewTypeForServiceInjection, "@does_not_exist")))
+
+			err := container.Start(ctx)
+			Expect(err).To(MatchError(ContainSubstring(`goldi: could not generate eager type "worker"`)))
+		})
+
+		It("should abort and roll back if the context is done", func() {
+			container.MustGet("db")
+			canceled, cancel := context.WithCancel(ctx)
+			cancel()
+
+			err := container.Start(canceled)
+			Expect(errors.Is(err, context.Canceled)).To(BeTrue())
+			Expect(log.Events).To(BeEmpty())
+		})
+	})
+
+	Describe("Stop", func() {
+		It("should stop the started instances in reverse order", func() {
+			registry.RegisterType("db", &Component{}, "db", "@log")
+			registry.Register("server", goldi.NewEagerType(goldi.NewStructType(&Component{}, "server", "@log", "@db")))
+
+			Expect(container.Start(ctx)).To(Succeed())
+			Expect(container.Stop(ctx)).To(Succeed())
+			Expect(log.Events).To(Equal([]string{"start db", "start server", "stop server", "stop db"}))
+		})
+
+		It("should not stop instances that have not been started", func() {
+			registry.RegisterType("db", &Component{}, "db", "@log")
+			container.MustGet("db")
+
+			Expect(container.Stop(ctx)).To(Succeed())
+			Expect(log.Events).To(BeEmpty())
+		})
+	})
+})
@@ -0,0 +1,133 @@
+package goldi
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// LookupParameter returns the value of the parameter with the given name from the given configuration.
+//
+// Parameter names are dotted paths into the configuration. A path is first looked up as flat key
+// (e.g. config["database.host"]) and otherwise walks through nested maps and slices:
+//
+//	config := map[string]interface{}{
+//	    "database": map[string]interface{}{
+//	        "primary": map[string]interface{}{"host": "localhost"},
+//	    },
+//	    "brokers": []interface{}{"kafka-1:9092", "kafka-2:9092"},
+//	}
+//
+//	LookupParameter(config, "database.primary.host") // "localhost", true
+//	LookupParameter(config, "brokers.1")             // "kafka-2:9092", true
+//	LookupParameter(config, "database")              // the whole database map, true
+//
+// Nested maps can either be of type map[string]interface{} or map[interface{}]interface{} (as created by some yaml
+// libraries). Slice elements are accessed by their index.
+func LookupParameter(config map[string]interface{}, name string) (interface{}, bool) {
+	if config == nil {
+		return nil, false
+	}
+
+	return lookupPath(reflect.ValueOf(config), name)
+}
+
+// SetParameterDefaults adds the given default values to the configuration without overwriting values that are
+// already set. Nested maps are merged recursively so a configuration that only sets database.host still gets the
+// default value of database.port. The RegisterParameters functions that are generated by goldigen use it.
+func SetParameterDefaults(config, defaults map[string]interface{}) {
+	for key, value := range defaults {
+		current, isSet := config[key]
+		if isSet == false {
+			config[key] = value
+			continue
+		}
+
+		if nestedDefaults, isMap := value.(map[string]interface{}); isMap {
+			setNestedDefaults(current, nestedDefaults)
+		}
+	}
+}
+
+// setNestedDefaults adds the defaults to the given value if it is a map[string]interface{} or
+// map[interface{}]interface{}. All other values are kept as they are.
+func setNestedDefaults(value interface{}, defaults map[string]interface{}) {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		SetParameterDefaults(v, defaults)
+	case map[interface{}]interface{}:
+		keys := make(map[string]interface{}, len(v))
+		for key := range v {
+			keys[fmt.Sprint(key)] = key
+		}
+
+		for key, defaultValue := range defaults {
+			mapKey, isSet := keys[key]
+			if isSet == false {
+				v[key] = defaultValue
+				continue
+			}
+
+			if nestedDefaults, isMap := defaultValue.(map[string]interface{}); isMap {
+				setNestedDefaults(v[mapKey], nestedDefaults)
+			}
+		}
+	}
+}
+
+func lookupPath(value reflect.Value, path string) (interface{}, bool) {
+	for value.Kind() == reflect.Interface || value.Kind() == reflect.Ptr {
+		if value.IsNil() {
+			return nil, false
+		}
+		value = value.Elem()
+	}
+
+	if child, exists := lookupKey(value, path); exists {
+		return child.Interface(), true
+	}
+
+	// try all prefixes of the path so flat keys which contain dots can also be used on every level
+	for i := strings.IndexByte(path, '.'); i >= 0; {
+		if child, exists := lookupKey(value, path[:i]); exists {
+			if result, isFound := lookupPath(child, path[i+1:]); isFound {
+				return result, true
+			}
+		}
+
+		next := strings.IndexByte(path[i+1:], '.')
+		if next < 0 {
+			break
+		}
+		i += next + 1
+	}
+
+	return nil, false
+}
+
+// lookupKey returns the element of a map or slice with the given key.
+func lookupKey(value reflect.Value, key string) (reflect.Value, bool) {
+	switch value.Kind() {
+	case reflect.Map:
+		switch value.Type().Key().Kind() {
+		case reflect.String:
+			child := value.MapIndex(reflect.ValueOf(key).Convert(value.Type().Key()))
+			return child, child.IsValid()
+		case reflect.Interface:
+			iter := value.MapRange()
+			for iter.Next() {
+				if mapKey := iter.Key().Elem(); mapKey.IsValid() && fmt.Sprint(mapKey.Interface()) == key {
+					return iter.Value(), true
+				}
+			}
+		}
+	case reflect.Slice, reflect.Array:
+		index, err := strconv.Atoi(key)
+		if err == nil && index >= 0 && index < value.Len() {
+			return value.Index(index), true
+		}
+	}
+
+	return reflect.Value{}, false
+}
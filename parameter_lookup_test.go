@@ -0,0 +1,101 @@
+package goldi_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/tarokamikaze/goldi"
+)
+
+var _ = Describe("LookupParameter", func() {
+	var config map[string]interface{}
+
+	lookup := func(name string) interface{} {
+		value, isDefined := goldi.LookupParameter(config, name)
+		Expect(isDefined).To(BeTrue(), name)
+		return value
+	}
+
+	BeforeEach(func() {
+		config = map[string]interface{}{
+			"database.dsn": "postgres://localhost/app",
+			"database": map[string]interface{}{
+				"primary": map[string]interface{}{"host": "db-1", "port": 5432},
+			},
+			"cache": map[interface{}]interface{}{
+				"host": "redis",
+				1:      "one",
+			},
+			"brokers": []interface{}{"kafka-1:9092", map[string]interface{}{"host": "kafka-2"}},
+			"empty":   nil,
+		}
+	})
+
+	It("should return flat parameters", func() {
+		Expect(lookup("database.dsn")).To(Equal("postgres://localhost/app"))
+	})
+
+	It("should walk through nested maps", func() {
+		Expect(lookup("database.primary.host")).To(Equal("db-1"))
+		Expect(lookup("database.primary.port")).To(Equal(5432))
+	})
+
+	It("should walk through maps with interface{} keys", func() {
+		Expect(lookup("cache.host")).To(Equal("redis"))
+		Expect(lookup("cache.1")).To(Equal("one"))
+	})
+
+	It("should walk through slices", func() {
+		Expect(lookup("brokers.0")).To(Equal("kafka-1:9092"))
+		Expect(lookup("brokers.1.host")).To(Equal("kafka-2"))
+	})
+
+	It("should return whole subtrees", func() {
+		Expect(lookup("database.primary")).To(Equal(map[string]interface{}{"host": "db-1", "port": 5432}))
+	})
+
+	It("should use flat keys that contain dots on every level", func() {
+		config["services"] = map[string]interface{}{"api.url": "http://localhost"}
+		Expect(lookup("services.api.url")).To(Equal("http://localhost"))
+	})
+
+	It("should return parameters that are explicitly set to nil", func() {
+		value, isDefined := goldi.LookupParameter(config, "empty")
+		Expect(isDefined).To(BeTrue())
+		Expect(value).To(BeNil())
+	})
+
+	It("should return false if the parameter does not exist", func() {
+		for _, name := range []string{"foo", "database.secondary.host", "brokers.2", "brokers.-1", "database.dsn.foo", "empty.foo"} {
+			_, isDefined := goldi.LookupParameter(config, name)
+			Expect(isDefined).To(BeFalse(), name)
+		}
+	})
+})
+
+var _ = Describe("SetParameterDefaults", func() {
+	It("should add the defaults without overwriting existing values", func() {
+		config := map[string]interface{}{"debug": true}
+		goldi.SetParameterDefaults(config, map[string]interface{}{"debug": false, "admin": "admin@example.com"})
+		Expect(config).To(Equal(map[string]interface{}{"debug": true, "admin": "admin@example.com"}))
+	})
+
+	It("should merge nested maps recursively", func() {
+		config := map[string]interface{}{
+			"database": map[string]interface{}{"host": "db.example.com"},
+			"cache":    map[interface{}]interface{}{"ttl": 60},
+			"hosts":    "a.example.com",
+		}
+
+		goldi.SetParameterDefaults(config, map[string]interface{}{
+			"database": map[string]interface{}{"host": "localhost", "port": 5432, "pool": map[string]interface{}{"size": 10}},
+			"cache":    map[string]interface{}{"ttl": 30, "size": 100},
+			"hosts":    map[string]interface{}{"primary": "b.example.com"},
+		})
+
+		Expect(config).To(Equal(map[string]interface{}{
+			"database": map[string]interface{}{"host": "db.example.com", "port": 5432, "pool": map[string]interface{}{"size": 10}},
+			"cache":    map[interface{}]interface{}{"ttl": 60, "size": 100},
+			"hosts":    "a.example.com",
+		}))
+	})
+})
@@ -0,0 +1,181 @@
+package goldi
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// A ParameterProcessor transforms the value of an environment variable or file parameter.
+//
+// Processors are applied by prefixing the name of the environment variable or file with the name of the processor:
+//
+//	%env(int:PORT)%                    the environment variable PORT as int
+//	%env(json:FEATURES)%               the environment variable FEATURES decoded from JSON
+//	%env(trim:file:DB_PASSWORD_FILE)%  the trimmed content of the file whose path is stored in DB_PASSWORD_FILE
+//	%file(trim:/run/secrets/db)%       the trimmed content of the file /run/secrets/db
+//
+// Processors can be chained and are applied from right to left. Use RegisterParameterProcessor to add your own.
+type ParameterProcessor func(value interface{}) (interface{}, error)
+
+// defaultProcessor is the name of the processor that falls back to another parameter if an environment variable is
+// not defined or empty (e.g. %env(default:fallback_param:API_KEY)%). It needs the container to resolve the fallback
+// parameter so it is not a regular ParameterProcessor.
+const defaultProcessor = "default"
+
+var (
+	processorsMu sync.RWMutex
+	processors   = map[string]ParameterProcessor{
+		"string": processString,
+		"int":    processInt,
+		"bool":   processBool,
+		"float":  processFloat,
+		"base64": processBase64,
+		"json":   processJSON,
+		"trim":   processTrim,
+		"file":   processFile,
+	}
+
+	errUndefinedEnvironmentVariable = errors.New("not defined")
+
+	// processedParameter matches the names of parameters like env(int:PORT) or file(/run/secrets/db)
+	processedParameter = regexp.MustCompile(`^(env|file)\((.+)\)$`)
+)
+
+// RegisterParameterProcessor registers a ParameterProcessor which can then be used in all environment variable and
+// file parameters. Registering a processor with an existing name replaces the existing processor.
+// RegisterParameterProcessor panics if the name is empty, contains a colon or is "default".
+func RegisterParameterProcessor(name string, processor ParameterProcessor) {
+	if name == "" || strings.Contains(name, ":") || name == defaultProcessor {
+		panic(fmt.Errorf("goldi: invalid parameter processor name %q", name))
+	}
+
+	processorsMu.Lock()
+	defer processorsMu.Unlock()
+	processors[name] = processor
+}
+
+func parameterProcessor(name string) (ParameterProcessor, bool) {
+	processorsMu.RLock()
+	defer processorsMu.RUnlock()
+	processor, exists := processors[name]
+	return processor, exists
+}
+
+// IsProcessedParameter returns whether the given parameter name refers to an environment variable or a file instead
+// of a configured parameter (e.g. env(DB_HOST) or file(/run/secrets/db_password)).
+func IsProcessedParameter(parameterName string) bool {
+	return processedParameter.MatchString(parameterName)
+}
+
+// resolveProcessedParameter returns the value of an environment variable or file parameter.
+func (r *ParameterResolver) resolveProcessedParameter(parameterName string) (interface{}, error) {
+	matches := processedParameter.FindStringSubmatch(parameterName)
+	source, expression := matches[1], matches[2]
+
+	value, err := r.processExpression(source, expression)
+	if err != nil {
+		return nil, fmt.Errorf("could not resolve parameter \"%%%s%%\": %w", parameterName, err)
+	}
+
+	return value, nil
+}
+
+// processExpression evaluates an expression like "int:PORT" or "default:fallback_param:API_KEY".
+// Only registered processor names are treated as prefix so file paths may contain colons.
+func (r *ParameterResolver) processExpression(source, expression string) (interface{}, error) {
+	name, rest, hasPrefix := strings.Cut(expression, ":")
+	if hasPrefix && name == defaultProcessor && source == "env" {
+		fallbackParameter, rest, isValid := strings.Cut(rest, ":")
+		if isValid == false {
+			return nil, fmt.Errorf("the default processor needs a fallback parameter and an environment variable")
+		}
+
+		value, err := r.processExpression(source, rest)
+		switch {
+		case errors.Is(err, errUndefinedEnvironmentVariable):
+		case err != nil:
+			return nil, err
+		case value != "":
+			return value, nil
+		}
+
+		if fallbackParameter == "" {
+			return nil, nil
+		}
+
+		fallbackValue, isConfigured := LookupParameter(r.Container.config(), fallbackParameter)
+		if isConfigured == false {
+			return nil, fmt.Errorf("the fallback parameter \"%%%s%%\" has not been defined", fallbackParameter)
+		}
+
+		return fallbackValue, nil
+	}
+
+	if processor, isProcessor := parameterProcessor(name); hasPrefix && isProcessor {
+		value, err := r.processExpression(source, rest)
+		if err != nil {
+			return nil, err
+		}
+
+		processed, err := processor(value)
+		if err != nil {
+			return nil, fmt.Errorf("processor %q failed: %w", name, err)
+		}
+
+		return processed, nil
+	}
+
+	switch source {
+	case "env":
+		value, isDefined := os.LookupEnv(expression)
+		if isDefined == false {
+			return nil, fmt.Errorf("the environment variable %q is %w", expression, errUndefinedEnvironmentVariable)
+		}
+		return value, nil
+	default:
+		return processFile(expression)
+	}
+}
+
+func processString(value interface{}) (interface{}, error) {
+	return fmt.Sprint(value), nil
+}
+
+func processInt(value interface{}) (interface{}, error) {
+	return strconv.Atoi(strings.TrimSpace(fmt.Sprint(value)))
+}
+
+func processBool(value interface{}) (interface{}, error) {
+	return strconv.ParseBool(strings.TrimSpace(fmt.Sprint(value)))
+}
+
+func processFloat(value interface{}) (interface{}, error) {
+	return strconv.ParseFloat(strings.TrimSpace(fmt.Sprint(value)), 64)
+}
+
+func processBase64(value interface{}) (interface{}, error) {
+	decoded, err := base64.StdEncoding.DecodeString(fmt.Sprint(value))
+	return string(decoded), err
+}
+
+func processJSON(value interface{}) (interface{}, error) {
+	var decoded interface{}
+	err := json.Unmarshal([]byte(fmt.Sprint(value)), &decoded)
+	return decoded, err
+}
+
+func processTrim(value interface{}) (interface{}, error) {
+	return strings.TrimSpace(fmt.Sprint(value)), nil
+}
+
+func processFile(value interface{}) (interface{}, error) {
+	content, err := os.ReadFile(fmt.Sprint(value))
+	return string(content), err
+}
@@ -0,0 +1,149 @@
+package goldi_test
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/tarokamikaze/goldi"
+)
+
+var _ = Describe("ParameterProcessor", func() {
+	var (
+		config   map[string]interface{}
+		resolver *goldi.ParameterResolver
+	)
+
+	setenv := func(name, value string) {
+		Expect(os.Setenv(name, value)).To(Succeed())
+		DeferCleanup(os.Unsetenv, name)
+	}
+
+	resolve := func(parameter string, expectedType reflect.Type) (interface{}, error) {
+		result, err := resolver.Resolve(reflect.ValueOf(parameter), expectedType)
+		if err != nil {
+			return nil, err
+		}
+		return result.Interface(), nil
+	}
+
+	stringType := reflect.TypeOf("")
+
+	BeforeEach(func() {
+		config = map[string]interface{}{}
+		resolver = goldi.NewParameterResolver(goldi.NewContainer(goldi.NewTypeRegistry(), config))
+	})
+
+	Describe("env parameters", func() {
+		It("should resolve environment variables", func() {
+			setenv("GOLDI_TEST_DB_HOST", "localhost")
+			Expect(resolve("%env(GOLDI_TEST_DB_HOST)%", stringType)).To(Equal("localhost"))
+		})
+
+		It("should resolve environment variables each time they are requested", func() {
+			setenv("GOLDI_TEST_DB_HOST", "localhost")
+			Expect(resolve("%env(GOLDI_TEST_DB_HOST)%", stringType)).To(Equal("localhost"))
+
+			setenv("GOLDI_TEST_DB_HOST", "db.example.com")
+			Expect(resolve("%env(GOLDI_TEST_DB_HOST)%", stringType)).To(Equal("db.example.com"))
+		})
+
+		It("should apply processors", func() {
+			setenv("GOLDI_TEST_PORT", "8080")
+			setenv("GOLDI_TEST_DEBUG", "true")
+			setenv("GOLDI_TEST_RATIO", "0.5")
+
+			Expect(resolve("%env(int:GOLDI_TEST_PORT)%", reflect.TypeOf(0))).To(Equal(8080))
+			Expect(resolve("%env(bool:GOLDI_TEST_DEBUG)%", reflect.TypeOf(false))).To(Equal(true))
+			Expect(resolve("%env(float:GOLDI_TEST_RATIO)%", reflect.TypeOf(0.0))).To(Equal(0.5))
+		})
+
+		It("should chain processors from right to left", func() {
+			setenv("GOLDI_TEST_FEATURES", "  eyJiZXRhIjp0cnVlfQ==  ") // {"beta":true}
+			Expect(resolve("%env(json:base64:trim:GOLDI_TEST_FEATURES)%", reflect.TypeOf(map[string]interface{}{}))).
+				To(Equal(map[string]interface{}{"beta": true}))
+		})
+
+		It("should fall back to another parameter if the environment variable is not defined or empty", func() {
+			config["default_api_key"] = "secret"
+			Expect(resolve("%env(default:default_api_key:GOLDI_TEST_API_KEY)%", stringType)).To(Equal("secret"))
+
+			setenv("GOLDI_TEST_API_KEY", "")
+			Expect(resolve("%env(default:default_api_key:GOLDI_TEST_API_KEY)%", stringType)).To(Equal("secret"))
+
+			setenv("GOLDI_TEST_API_KEY", "from-env")
+			Expect(resolve("%env(default:default_api_key:GOLDI_TEST_API_KEY)%", stringType)).To(Equal("from-env"))
+		})
+
+		It("should resolve to nil if the fallback parameter is empty", func() {
+			Expect(resolve("%env(default::GOLDI_TEST_API_KEY)%", reflect.TypeOf((*string)(nil)))).To(BeNil())
+		})
+
+		It("should be usable in interpolated strings", func() {
+			setenv("GOLDI_TEST_DB_HOST", "localhost")
+			config["db.port"] = 5432
+			Expect(resolve("postgres://%env(GOLDI_TEST_DB_HOST)%:%db.port%/app", stringType)).To(Equal("postgres://localhost:5432/app"))
+		})
+
+		It("should return an error if the environment variable is not defined", func() {
+			_, err := resolve("%env(GOLDI_TEST_UNDEFINED)%", stringType)
+			Expect(err).To(MatchError(`could not resolve parameter "%env(GOLDI_TEST_UNDEFINED)%": the environment variable "GOLDI_TEST_UNDEFINED" is not defined`))
+		})
+
+		It("should return an error if a processor fails", func() {
+			setenv("GOLDI_TEST_PORT", "http")
+			_, err := resolve("%env(int:GOLDI_TEST_PORT)%", reflect.TypeOf(0))
+			Expect(err).To(MatchError(ContainSubstring(`could not resolve parameter "%env(int:GOLDI_TEST_PORT)%": processor "int" failed`)))
+		})
+
+		It("should not fall back if a processor fails", func() {
+			config["default_port"] = 80
+			setenv("GOLDI_TEST_PORT", "http")
+			_, err := resolve("%env(default:default_port:int:GOLDI_TEST_PORT)%", reflect.TypeOf(0))
+			Expect(err).To(MatchError(ContainSubstring(`processor "int" failed`)))
+		})
+	})
+
+	Describe("file parameters", func() {
+		var path string
+
+		BeforeEach(func() {
+			path = filepath.Join(GinkgoT().TempDir(), "db_password")
+			Expect(os.WriteFile(path, []byte("s3cr3t\n"), 0600)).To(Succeed())
+		})
+
+		It("should resolve to the file content", func() {
+			Expect(resolve("%file("+path+")%", stringType)).To(Equal("s3cr3t\n"))
+			Expect(resolve("%file(trim:"+path+")%", stringType)).To(Equal("s3cr3t"))
+		})
+
+		It("should read files whose path is stored in an environment variable", func() {
+			setenv("GOLDI_TEST_PASSWORD_FILE", path)
+			Expect(resolve("%env(trim:file:GOLDI_TEST_PASSWORD_FILE)%", stringType)).To(Equal("s3cr3t"))
+		})
+
+		It("should return an error if the file does not exist", func() {
+			_, err := resolve("%file(/does/not/exist)%", stringType)
+			Expect(err).To(MatchError(ContainSubstring(`could not resolve parameter "%file(/does/not/exist)%"`)))
+		})
+	})
+
+	Describe("RegisterParameterProcessor", func() {
+		It("should register custom processors", func() {
+			goldi.RegisterParameterProcessor("upper", func(value interface{}) (interface{}, error) {
+				return strings.ToUpper(value.(string)), nil
+			})
+
+			setenv("GOLDI_TEST_REGION", "eu-west-1")
+			Expect(resolve("%env(upper:GOLDI_TEST_REGION)%", stringType)).To(Equal("EU-WEST-1"))
+		})
+
+		It("should panic if the name is invalid", func() {
+			Expect(func() { goldi.RegisterParameterProcessor("default", nil) }).To(Panic())
+			Expect(func() { goldi.RegisterParameterProcessor("a:b", nil) }).To(Panic())
+		})
+	})
+})
@@ -1,11 +1,23 @@
 package goldi
 
-import "reflect"
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"reflect"
+	"slices"
+	"strings"
+)
 
 // The ParameterResolver is used by type factories to resolve the values of the dynamic factory arguments
 // (parameters and other type references).
 type ParameterResolver struct {
 	Container *Container
+
+	resolution *resolution // the resolution this resolver belongs to or nil if it has not been created by the container
+	inner      interface{} // the instance that is decorated if this resolver is used by a decorator
+	hasInner   bool
 }
 
 // NewParameterResolver creates a new ParameterResolver and initializes it with the given Container.
@@ -18,46 +30,184 @@ func NewParameterResolver(container *Container) *ParameterResolver {
 
 // Resolve takes a parameter and resolves any references to configuration parameter values or type references.
 // If the type of `parameter` is not a parameter or type reference it is returned as is.
-// Parameters must always have the form `%my.beautiful.param%.
+// Parameters must always have the form `%my.beautiful.param%. The parameter name is a dotted path into nested
+// configuration maps and slices (see LookupParameter). Maps can also be decoded into struct arguments.
+// Parameters can also be embedded into strings like `postgres://%db.user%@%db.host%/app`. In this case all parameters
+// are replaced with the string representation of their configured values and `%%` can be used as literal percent sign.
 // Type references must have the form `@my_type.bla`.
 // It is also legal to request an optional type using the syntax `@?my_optional_type`.
 // If this type is not registered Resolve will not return an error but instead give you the null value
 // of the expected type.
+// Tagged references have the form `!tagged my.tag` and resolve to a slice of all types with that tag
+// (see Container.GetTagged). They can only be used if the expected type is a slice.
 func (r *ParameterResolver) Resolve(parameter reflect.Value, expectedType reflect.Type) (reflect.Value, error) {
 	if parameter.Kind() != reflect.String {
 		return parameter, nil
 	}
 
 	stringParameter := parameter.Interface().(string)
-	if IsParameterOrTypeReference(stringParameter) == false {
+	switch {
+	case IsTypeReference(stringParameter):
+		return r.resolveTypeReference(stringParameter, expectedType)
+	case IsTaggedReference(stringParameter):
+		return r.resolveTaggedReference(stringParameter, expectedType)
+	case isInterpolated(stringParameter):
+		return r.interpolateParameters(stringParameter, expectedType)
+	case IsParameter(stringParameter):
+		return r.resolveParameter(parameter, stringParameter, expectedType)
+	default:
 		return parameter, nil
 	}
+}
 
-	if IsTypeReference(stringParameter) {
-		return r.resolveTypeReference(stringParameter, expectedType)
+func (r *ParameterResolver) resolveParameter(parameter reflect.Value, stringParameter string, expectedType reflect.Type) (reflect.Value, error) {
+	configuredValue, isConfigured, err := r.parameterValue(stringParameter[1:len(stringParameter)-1], nil)
+	if err != nil {
+		return reflect.Value{}, err
 	}
 
-	return r.resolveParameter(parameter, stringParameter, expectedType), nil
-}
-
-func (r *ParameterResolver) resolveParameter(parameter reflect.Value, stringParameter string, expectedType reflect.Type) reflect.Value {
-	parameterName := stringParameter[1 : len(stringParameter)-1]
-	configuredValue, isConfigured := r.Container.Config[parameterName]
 	if isConfigured == false {
-		return parameter
+		return parameter, nil
 	}
 
 	// Use cached reflection operations
 	cache := GetGlobalReflectionCache()
-	parameter = reflect.New(expectedType).Elem()
-	parameter.Set(cache.GetValue(configuredValue))
-	return parameter
+	value, err := convertValue(cache.GetValue(configuredValue), expectedType)
+	if err != nil {
+		return reflect.Value{}, &ParameterError{
+			Parameter:     stringParameter,
+			Value:         configuredValue,
+			ExpectedType:  expectedType,
+			ArgumentIndex: -1,
+			Err:           err,
+		}
+	}
+
+	return value, nil
+}
+
+// parameterValue returns the value of the parameter with the given name. The name may contain an inline default
+// (see SplitParameterDefault) which is used if the parameter is not defined. String values that refer to other
+// parameters are resolved recursively and path contains the names of all parameters that are currently being resolved.
+//
+// If the parameter is not defined and has no default, an error is returned if the container uses StrictParameters.
+func (r *ParameterResolver) parameterValue(name string, path []string) (interface{}, bool, error) {
+	name, defaultValue, hasDefault := SplitParameterDefault(name)
+	if slices.Contains(path, name) {
+		cycle := append(path[slices.Index(path, name):], name)
+		return nil, false, fmt.Errorf("goldi: circular parameter reference detected: %s", strings.Join(cycle, " -> "))
+	}
+
+	value, isDefined, err := r.lookupParameter(name)
+	switch {
+	case isDefined:
+	case hasDefault && (err == nil || errors.Is(err, errUndefinedEnvironmentVariable) || errors.Is(err, fs.ErrNotExist)):
+		value = defaultValue
+	case err != nil:
+		return nil, false, err
+	case r.Container.StrictParameters:
+		return nil, false, fmt.Errorf("goldi: the parameter \"%%%s%%\" has not been defined", name)
+	default:
+		return nil, false, nil
+	}
+
+	if s, isString := value.(string); isString {
+		value, err = r.expandParameters(s, append(path, name))
+		if err != nil {
+			return nil, false, err
+		}
+	}
+
+	return value, true, nil
+}
+
+// lookupParameter returns the value of the parameter with the given name.
+// Environment variable and file parameters are resolved each time they are requested (see ParameterProcessor).
+func (r *ParameterResolver) lookupParameter(parameterName string) (interface{}, bool, error) {
+	if IsProcessedParameter(parameterName) {
+		value, err := r.resolveProcessedParameter(parameterName)
+		return value, err == nil, err
+	}
+
+	value, isConfigured := LookupParameter(r.Container.config(), parameterName)
+	return value, isConfigured, nil
+}
+
+// expandParameters resolves the parameters a configured string value refers to.
+// A string that consists of exactly one parameter resolves to the value of that parameter.
+func (r *ParameterResolver) expandParameters(s string, path []string) (interface{}, error) {
+	switch {
+	case isInterpolated(s):
+		return r.interpolate(s, path)
+	case IsParameter(s):
+		value, isDefined, err := r.parameterValue(s[1:len(s)-1], path)
+		if err != nil || isDefined == false {
+			return s, err
+		}
+		return value, nil
+	default:
+		return s, nil
+	}
+}
+
+// interpolateParameters replaces all parameters which are embedded in the given string with their configured values
+// and converts the result into the expected type just like a configured string value (e.g. into a time.Duration).
+// Parameters that are not configured are left untouched.
+func (r *ParameterResolver) interpolateParameters(s string, expectedType reflect.Type) (reflect.Value, error) {
+	interpolated, err := r.interpolate(s, nil)
+	if err != nil {
+		return reflect.Value{}, err
+	}
+
+	value, err := convertValue(reflect.ValueOf(interpolated), expectedType)
+	if err != nil {
+		return reflect.Value{}, &ParameterError{
+			Parameter:     s,
+			Value:         interpolated,
+			ExpectedType:  expectedType,
+			ArgumentIndex: -1,
+			Err:           err,
+		}
+	}
+
+	return value, nil
+}
+
+func (r *ParameterResolver) interpolate(s string, path []string) (string, error) {
+	var err error
+	interpolated := parameterPlaceholder.ReplaceAllStringFunc(s, func(placeholder string) string {
+		if placeholder == "%%" || err != nil {
+			return "%"
+		}
+
+		value, isDefined, lookupErr := r.parameterValue(placeholder[1:len(placeholder)-1], path)
+		if lookupErr != nil || isDefined == false {
+			err = lookupErr
+			return placeholder
+		}
+
+		return fmt.Sprint(value)
+	})
+
+	return interpolated, err
 }
 
 func (r *ParameterResolver) resolveTypeReference(typeIDAndPrefix string, expectedType reflect.Type) (reflect.Value, error) {
 	t := NewTypeID(typeIDAndPrefix)
 
-	typeInstance, typeDefined, err := r.Container.get(t.ID)
+	var typeInstance interface{}
+	var typeDefined bool
+	var err error
+	if t.ID == InnerTypeID {
+		if r.hasInner == false {
+			return reflect.Value{}, fmt.Errorf(`the type "@%s" can only be referenced by decorators`, InnerTypeID)
+		}
+
+		typeInstance, typeDefined = r.inner, true
+	} else {
+		typeInstance, typeDefined, err = r.get(t.ID)
+	}
+
 	if err != nil {
 		return reflect.Zero(expectedType), err
 	}
@@ -96,3 +246,71 @@ func (r *ParameterResolver) resolveTypeReference(typeIDAndPrefix string, expecte
 	result.Set(cache.GetValue(typeInstance))
 	return result, nil
 }
+
+func (r *ParameterResolver) resolveTaggedReference(reference string, expectedType reflect.Type) (reflect.Value, error) {
+	if expectedType.Kind() != reflect.Slice {
+		return reflect.Value{}, fmt.Errorf("the tagged reference %q can only be injected into a slice but the expected type is %v", reference, expectedType)
+	}
+
+	tagName := strings.TrimSpace(strings.TrimPrefix(reference, taggedReferencePrefix))
+	taggedTypes := r.Container.FindTaggedTypes(tagName)
+	result := reflect.MakeSlice(expectedType, 0, len(taggedTypes))
+	for _, tagged := range taggedTypes {
+		instance, err := r.Get(tagged.TypeID)
+		if err != nil {
+			return reflect.Zero(expectedType), err
+		}
+
+		instanceValue := reflect.ValueOf(instance)
+		if instanceValue.IsValid() == false {
+			instanceValue = reflect.Zero(expectedType.Elem())
+		}
+
+		if instanceValue.Type().AssignableTo(expectedType.Elem()) == false {
+			return reflect.Value{}, newTypeReferenceError(tagged.TypeID, instance,
+				`the tagged type "@%s" (type %T) is not assignable to the expected type %v`, tagged.TypeID, instance, expectedType.Elem(),
+			)
+		}
+
+		result = reflect.Append(result, instanceValue)
+	}
+
+	return result, nil
+}
+
+// Get retrieves a type from the container just like Container.Get.
+// Type factories that need to resolve other types themselves should always use this method instead of calling
+// Container.Get directly. This way the requested type is generated as part of the resolution of the type that is
+// currently generated which is required to detect circular dependencies between the types.
+func (r *ParameterResolver) Get(typeID string) (interface{}, error) {
+	instance, isDefined, err := r.get(typeID)
+	if err != nil {
+		return nil, err
+	}
+
+	if isDefined == false {
+		return nil, newUnknownTypeReferenceError(typeID, "no such type has been defined")
+	}
+
+	return instance, nil
+}
+
+func (r *ParameterResolver) get(typeID string) (interface{}, bool, error) {
+	res := r.resolution
+	if res == nil {
+		res = newResolution(context.Background())
+	}
+
+	return r.Container.get(typeID, res)
+}
+
+// Context returns the context that has been passed to Container.GetContext when the resolver is used to generate
+// a type. Type factories should use this context to abort slow operations.
+// If the resolver has not been created by the container, context.Background() is returned.
+func (r *ParameterResolver) Context() context.Context {
+	if r.resolution == nil {
+		return context.Background()
+	}
+
+	return r.resolution.ctx
+}
@@ -1,7 +1,11 @@
 package goldi_test
 
 import (
+	"context"
+	"fmt"
+	"net/url"
 	"reflect"
+	"time"
 
 	"github.com/tarokamikaze/goldi"
 	. "github.com/onsi/ginkgo/v2"
@@ -77,6 +81,286 @@ var _ = Describe("ParameterResolver", func() {
 				Expect(result.Interface()).To(Equal(config["bar"]))
 			})
 		})
+
+		Context("when the parameter is a path into a nested configuration", func() {
+			type DatabaseConfig struct {
+				Host           string
+				Port           int
+				MaxConnections int
+				Replicas       []string
+			}
+
+			BeforeEach(func() {
+				config["database"] = map[interface{}]interface{}{
+					"host":            "localhost",
+					"port":            5432.0,
+					"max_connections": 10,
+					"replicas":        []interface{}{"replica-1", "replica-2"},
+				}
+			})
+
+			It("should resolve nested values", func() {
+				parameter := reflect.ValueOf("%database.host%")
+
+				result, err := resolver.Resolve(parameter, parameter.Type())
+				Expect(err).NotTo(HaveOccurred())
+				Expect(result.Interface()).To(Equal("localhost"))
+			})
+
+			It("should resolve nested values in embedded parameters", func() {
+				parameter := reflect.ValueOf("%database.host%:%database.port%")
+
+				result, err := resolver.Resolve(parameter, parameter.Type())
+				Expect(err).NotTo(HaveOccurred())
+				Expect(result.Interface()).To(Equal("localhost:5432"))
+			})
+
+			It("should inject whole subtrees as map", func() {
+				parameter := reflect.ValueOf("%database%")
+
+				result, err := resolver.Resolve(parameter, reflect.TypeOf(map[string]interface{}{}))
+				Expect(err).NotTo(HaveOccurred())
+				Expect(result.Interface()).To(HaveKeyWithValue("host", "localhost"))
+			})
+
+			It("should decode subtrees into structs", func() {
+				parameter := reflect.ValueOf("%database%")
+
+				result, err := resolver.Resolve(parameter, reflect.TypeOf(&DatabaseConfig{}))
+				Expect(err).NotTo(HaveOccurred())
+				Expect(result.Interface()).To(Equal(&DatabaseConfig{
+					Host:           "localhost",
+					Port:           5432,
+					MaxConnections: 10,
+					Replicas:       []string{"replica-1", "replica-2"},
+				}))
+			})
+
+			It("should return an error if a subtree can not be decoded", func() {
+				config["database"].(map[interface{}]interface{})["timeout"] = "10s"
+				parameter := reflect.ValueOf("%database%")
+
+				_, err := resolver.Resolve(parameter, reflect.TypeOf(DatabaseConfig{}))
+				Expect(err).To(MatchError(ContainSubstring(`can not decode "timeout" into goldi_test.DatabaseConfig`)))
+				Expect(err.(*goldi.ParameterError).Parameter).To(Equal("%database%"))
+			})
+		})
+
+		Context("when the parameter has an inline default", func() {
+			It("should use the default if the parameter has not been defined", func() {
+				parameter := reflect.ValueOf("%http.port:8080%")
+
+				result, err := resolver.Resolve(parameter, reflect.TypeOf(0))
+				Expect(err).NotTo(HaveOccurred())
+				Expect(result.Interface()).To(Equal(8080))
+			})
+
+			It("should use the configured value if the parameter has been defined", func() {
+				config["http.port"] = 80
+				parameter := reflect.ValueOf("%http.port:8080%")
+
+				result, err := resolver.Resolve(parameter, reflect.TypeOf(0))
+				Expect(err).NotTo(HaveOccurred())
+				Expect(result.Interface()).To(Equal(80))
+			})
+
+			It("should use defaults in embedded parameters", func() {
+				parameter := reflect.ValueOf("http://%http.host:localhost%:%http.port:8080%")
+
+				result, err := resolver.Resolve(parameter, parameter.Type())
+				Expect(err).NotTo(HaveOccurred())
+				Expect(result.Interface()).To(Equal("http://localhost:8080"))
+			})
+
+			It("should use the default if an environment variable is not defined", func() {
+				parameter := reflect.ValueOf("%env(int:GOLDI_TEST_UNDEFINED_PORT):8080%")
+
+				result, err := resolver.Resolve(parameter, reflect.TypeOf(0))
+				Expect(err).NotTo(HaveOccurred())
+				Expect(result.Interface()).To(Equal(8080))
+			})
+		})
+
+		Context("when the parameter refers to other parameters", func() {
+			It("should resolve the referenced parameter", func() {
+				config["default_timeout"] = "30s"
+				config["http.timeout"] = "%default_timeout%"
+				parameter := reflect.ValueOf("%http.timeout%")
+
+				result, err := resolver.Resolve(parameter, reflect.TypeOf(time.Duration(0)))
+				Expect(err).NotTo(HaveOccurred())
+				Expect(result.Interface()).To(Equal(30 * time.Second))
+			})
+
+			It("should keep the type of the referenced parameter", func() {
+				config["default_port"] = 8080
+				config["http.port"] = "%default_port%"
+				parameter := reflect.ValueOf("%http.port%")
+
+				result, err := resolver.Resolve(parameter, reflect.TypeOf(0))
+				Expect(err).NotTo(HaveOccurred())
+				Expect(result.Interface()).To(Equal(8080))
+			})
+
+			It("should interpolate parameters embedded in configured values", func() {
+				config["db.host"] = "localhost"
+				config["db.dsn"] = "postgres://%db.host%/%db.name:app%"
+				parameter := reflect.ValueOf("%db.dsn%")
+
+				result, err := resolver.Resolve(parameter, parameter.Type())
+				Expect(err).NotTo(HaveOccurred())
+				Expect(result.Interface()).To(Equal("postgres://localhost/app"))
+			})
+
+			It("should return an error if the parameters refer to each other", func() {
+				config["a"] = "%b%"
+				config["b"] = "prefix-%c%"
+				config["c"] = "%a%"
+				parameter := reflect.ValueOf("%a%")
+
+				_, err := resolver.Resolve(parameter, parameter.Type())
+				Expect(err).To(MatchError("goldi: circular parameter reference detected: a -> b -> c -> a"))
+			})
+		})
+
+		Context("when the container uses strict parameters", func() {
+			BeforeEach(func() {
+				container.StrictParameters = true
+			})
+
+			It("should return an error if the parameter has not been defined", func() {
+				parameter := reflect.ValueOf("%foo%")
+
+				_, err := resolver.Resolve(parameter, parameter.Type())
+				Expect(err).To(MatchError(`goldi: the parameter "%foo%" has not been defined`))
+			})
+
+			It("should return an error if an embedded parameter has not been defined", func() {
+				config["db.host"] = "localhost"
+				parameter := reflect.ValueOf("postgres://%db.user%@%db.host%/app")
+
+				_, err := resolver.Resolve(parameter, parameter.Type())
+				Expect(err).To(MatchError(`goldi: the parameter "%db.user%" has not been defined`))
+			})
+
+			It("should return an error if a referenced parameter has not been defined", func() {
+				config["http.timeout"] = "%default_timeout%"
+				parameter := reflect.ValueOf("%http.timeout%")
+
+				_, err := resolver.Resolve(parameter, parameter.Type())
+				Expect(err).To(MatchError(`goldi: the parameter "%default_timeout%" has not been defined`))
+			})
+
+			It("should not rewrite printf and strftime format strings", func() {
+				for _, format := range []string{"%H:%M:%S", "%s:%d", "%Y-%m-%d %H:%M:%S"} {
+					parameter := reflect.ValueOf(format)
+
+					result, err := resolver.Resolve(parameter, parameter.Type())
+					Expect(err).NotTo(HaveOccurred())
+					Expect(result.Interface()).To(Equal(format))
+				}
+			})
+
+			It("should use quoted empty defaults", func() {
+				parameter := reflect.ValueOf(`%prefix:""%`)
+
+				result, err := resolver.Resolve(parameter, parameter.Type())
+				Expect(err).NotTo(HaveOccurred())
+				Expect(result.Interface()).To(Equal(""))
+			})
+
+			It("should not treat percent-encoded strings as parameters", func() {
+				parameter := reflect.ValueOf("a%20b%2Fc")
+
+				result, err := resolver.Resolve(parameter, parameter.Type())
+				Expect(err).NotTo(HaveOccurred())
+				Expect(result.Interface()).To(Equal("a%20b%2Fc"))
+			})
+
+			It("should still use inline defaults", func() {
+				parameter := reflect.ValueOf("%foo:bar%")
+
+				result, err := resolver.Resolve(parameter, parameter.Type())
+				Expect(err).NotTo(HaveOccurred())
+				Expect(result.Interface()).To(Equal("bar"))
+			})
+
+			It("should make Get return the error", func() {
+				container.RegisterType("mock", NewMockTypeWithArgs, "%undefined%", true)
+
+				_, err := container.Get("mock")
+				Expect(err).To(MatchError(`goldi: error while generating type "mock": goldi: the parameter "%undefined%" has not been defined`))
+			})
+		})
+
+		Context("when parameters are embedded in a string", func() {
+			It("should replace all parameters with their configured values", func() {
+				config["db.user"] = "goldi"
+				config["db.host"] = "localhost"
+				config["db.port"] = 5432
+				parameter := reflect.ValueOf("postgres://%db.user%@%db.host%:%db.port%/app")
+
+				result, err := resolver.Resolve(parameter, parameter.Type())
+				Expect(err).NotTo(HaveOccurred())
+				Expect(result.Interface()).To(Equal("postgres://goldi@localhost:5432/app"))
+			})
+
+			It("should replace %% with a literal percent sign", func() {
+				config["ratio"] = 42
+				parameter := reflect.ValueOf("%ratio%%%")
+
+				result, err := resolver.Resolve(parameter, parameter.Type())
+				Expect(err).NotTo(HaveOccurred())
+				Expect(result.Interface()).To(Equal("42%"))
+			})
+
+			It("should leave parameters that have not been defined untouched", func() {
+				config["host"] = "localhost"
+				parameter := reflect.ValueOf("%scheme%://%host%")
+
+				result, err := resolver.Resolve(parameter, parameter.Type())
+				Expect(err).NotTo(HaveOccurred())
+				Expect(result.Interface()).To(Equal("%scheme%://localhost"))
+			})
+
+			It("should convert the result to the expected string type", func() {
+				type DSN string
+				config["host"] = "localhost"
+				parameter := reflect.ValueOf("tcp://%host%")
+
+				result, err := resolver.Resolve(parameter, reflect.TypeOf(DSN("")))
+				Expect(err).NotTo(HaveOccurred())
+				Expect(result.Interface()).To(Equal(DSN("tcp://localhost")))
+			})
+
+			It("should convert the result to a time.Duration", func() {
+				config["a"] = 1
+				config["b"] = "m"
+				parameter := reflect.ValueOf("%a%%b%")
+
+				result, err := resolver.Resolve(parameter, reflect.TypeOf(time.Duration(0)))
+				Expect(err).NotTo(HaveOccurred())
+				Expect(result.Interface()).To(Equal(time.Minute))
+			})
+
+			It("should convert the result to a *url.URL", func() {
+				config["scheme"] = "https"
+				config["host"] = "example.com"
+				container.RegisterType("client", func(u *url.URL) *url.URL { return u }, "%scheme%://%host%")
+
+				client, err := container.Get("client")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(client.(*url.URL).String()).To(Equal("https://example.com"))
+			})
+
+			It("should return a ParameterError if the result can not be converted", func() {
+				config["timeout"] = 30
+				container.RegisterType("client", func(d time.Duration) time.Duration { return d }, "%timeout%seconds")
+
+				_, err := container.Get("client")
+				Expect(err).To(MatchError(ContainSubstring(`goldi: can not use parameter "%timeout%seconds" (value "30seconds") as time.Duration for argument 1 of type "client"`)))
+			})
+		})
 	})
 
 	Context("with type references", func() {
@@ -160,4 +444,33 @@ var _ = Describe("ParameterResolver", func() {
 			})
 		})
 	})
+
+	Describe("Context()", func() {
+		It("should return the background context if the resolver has not been created by the container", func() {
+			Expect(resolver.Context()).To(Equal(context.Background()))
+		})
+
+		It("should return the context of the current resolution", func() {
+			ctx := context.WithValue(context.Background(), contextKey("request"), 42)
+			container.Register("foo", goldi.NewType(func(ctx context.Context) *MockType {
+				return &MockType{StringParameter: fmt.Sprint(ctx.Value(contextKey("request")))}
+			}))
+
+			foo, err := container.GetContext(ctx, "foo")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(foo.(*MockType).StringParameter).To(Equal("42"))
+		})
+	})
+
+	Describe("Get()", func() {
+		It("should retrieve the type from the container", func() {
+			container.Register("foo", goldi.NewType(NewMockType))
+			Expect(resolver.Get("foo")).To(BeIdenticalTo(container.MustGet("foo")))
+		})
+
+		It("should return an error if the type is not defined", func() {
+			_, err := resolver.Get("foo")
+			Expect(err).To(MatchError("no such type has been defined"))
+		})
+	})
 })
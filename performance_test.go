@@ -54,6 +54,30 @@ func BenchmarkContainerConcurrency(b *testing.B) {
 	})
 }
 
+// BenchmarkLargeRegistryLookup tests uncached lookups in a container with many registered types
+func BenchmarkLargeRegistryLookup(b *testing.B) {
+	registry := NewTypeRegistry()
+	for i := 0; i < 500; i++ {
+		registry.RegisterType(fmt.Sprintf("type_%d", i), func() string { return "test" })
+	}
+	registry.Register("prototype", NewScopedType(NewType(func() string { return "test" }), Prototype))
+	container := NewContainer(registry, map[string]interface{}{})
+
+	b.Run("Prototype", func(b *testing.B) {
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			_, _ = container.Get("prototype")
+		}
+	})
+
+	b.Run("Scope", func(b *testing.B) {
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			_, _ = container.NewScope().Get("type_1")
+		}
+	})
+}
+
 // BenchmarkMemoryPool tests memory pool performance
 func BenchmarkMemoryPool(b *testing.B) {
 	pool := NewMemoryPool()
@@ -12,6 +12,7 @@ type proxyType struct {
 }
 
 // NewProxyType returns a TypeFactory that uses a function of another type to generate a result.
+// Just like the factory functions of NewType the function may return an error as second return parameter.
 //
 // Goldigen yaml syntax example:
 //     logger:
@@ -38,9 +39,11 @@ func (t *proxyType) Arguments() []interface{} {
 }
 
 func (t *proxyType) Generate(resolver *ParameterResolver) (interface{}, error) {
-	referencedType, err := resolver.Container.Get(t.typeID.ID)
-	if err != nil {
+	referencedType, err := resolver.Get(t.typeID.ID)
+	if _, isUnknownType := err.(UnknownTypeReferenceError); isUnknownType {
 		return nil, fmt.Errorf("could not generate proxy type %s : type %s does not exist", t.typeID, t.typeID.ID)
+	} else if err != nil {
+		return nil, fmt.Errorf("could not generate proxy type %s : %w", t.typeID, err)
 	}
 
 	v := reflect.ValueOf(referencedType)
@@ -15,6 +15,14 @@ func (lp *LoggerProvider) GetLogger(name string) *SimpleLogger {
 	return &SimpleLogger{Name: name}
 }
 
+func (lp *LoggerProvider) OpenLogger(name string) (*SimpleLogger, error) {
+	if name == "" {
+		return nil, fmt.Errorf("the logger name must not be empty")
+	}
+
+	return &SimpleLogger{Name: name}, nil
+}
+
 // Let's assume that we have a LoggerProvider type that produces configured instances
 // of a Logger each time we call LoggerProvider.GetLogger(loggerName string).
 //
@@ -79,6 +87,17 @@ var _ = Describe("proxyType", func() {
 			Expect(generated.(*SimpleLogger).Name).To(Equal("My logger"))
 		})
 
+		It("should support methods that return an error", func() {
+			container.Register("logger_provider", goldi.NewStructType(LoggerProvider{}))
+
+			generated, err := goldi.NewProxyType("logger_provider", "OpenLogger", "My logger").Generate(resolver)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(generated.(*SimpleLogger).Name).To(Equal("My logger"))
+
+			_, err = goldi.NewProxyType("logger_provider", "OpenLogger", "").Generate(resolver)
+			Expect(err).To(MatchError("the logger name must not be empty"))
+		})
+
 		It("should return an error if the referenced type has no such method", func() {
 			typeDef := goldi.NewProxyType("foobar", "DoStuff")
 
@@ -0,0 +1,174 @@
+package goldi
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sync"
+)
+
+// A resolution holds the state of a single call to Container.GetContext including all the types that are generated
+// recursively to satisfy the dependencies of the requested type. The resolution is passed to the type factories
+// as part of their ParameterResolver so every type reference they resolve belongs to the same resolution.
+//
+// The resolution also tracks which types are currently generated so circular dependencies can be detected.
+type resolution struct {
+	ctx        context.Context
+	path       []flightKey // the types that are currently generated by this resolution (outermost first)
+	waitingFor *flightCall // the call this resolution is currently waiting for (guarded by flightGroup.mu)
+}
+
+// A flightGroup makes sure each cached type is generated exactly once even if it is requested by many goroutines
+// at the same time. The group is shared by a container and all its scopes (see Container.NewScope).
+//
+// Goroutines that request a type which is currently being generated wait for the result instead of generating the
+// type again. Before a resolution starts to wait, the group checks whether the generating resolution is (directly or
+// indirectly) waiting for the requesting resolution. This would be a circular dependency and waiting would never end.
+type flightGroup struct {
+	mu    sync.Mutex
+	calls map[flightKey]*flightCall
+}
+
+type flightKey struct {
+	container *Container
+	typeID    string
+}
+
+type flightCall struct {
+	key      flightKey
+	done     chan struct{}
+	owner    *resolution
+	instance interface{}
+	err      error
+	stale    bool // set by Container.evict if the type has been invalidated while it is generated (guarded by flightGroup.mu)
+}
+
+func newResolution(ctx context.Context) *resolution {
+	return &resolution{ctx: ctx}
+}
+
+func newFlightGroup() *flightGroup {
+	return &flightGroup{calls: map[flightKey]*flightCall{}}
+}
+
+// waitsFor returns true if the resolution that owns the given call is equal to res or waits for it.
+// The caller must hold g.mu.
+func (g *flightGroup) waitsFor(call *flightCall, res *resolution) bool {
+	for owner := call.owner; owner != res; owner = owner.waitingFor.owner {
+		if owner.waitingFor == nil {
+			return false
+		}
+	}
+
+	return true
+}
+
+// circularDependency creates the error for a call that res can not wait for because the owner of the call waits for res.
+// The path of the error is assembled from the paths of all resolutions that are involved, starting at the given call.
+// A resolution which waits for a call does not change its path so it is safe to access it while holding g.mu.
+func (g *flightGroup) circularDependency(call *flightCall, res *resolution) CircularDependencyError {
+	var cycle []flightKey
+	for start := call; ; call = call.owner.waitingFor {
+		i := max(slices.Index(call.owner.path, call.key), 0)
+		cycle = append(cycle, call.owner.path[i:]...)
+		if call.owner == res {
+			return newCircularDependencyError(cycle, start.key)
+		}
+	}
+}
+
+// generateCached returns the cached instance of the given type or generates and caches it.
+// Concurrent calls for the same type are coalesced so the type factory is called only once.
+func (c *Container) generateCached(typeID string, generator TypeFactory, res *resolution) (interface{}, bool, error) {
+	if cached, ok := c.typeCache.Load(typeID); ok {
+		return cached, true, nil
+	}
+
+	g, key := c.flights, flightKey{c, typeID}
+	g.mu.Lock()
+
+	// the instance might have been stored while we were waiting for the lock
+	if cached, ok := c.typeCache.Load(typeID); ok {
+		g.mu.Unlock()
+		return cached, true, nil
+	}
+
+	if call, isInFlight := g.calls[key]; isInFlight {
+		if g.waitsFor(call, res) {
+			err := g.circularDependency(call, res)
+			g.mu.Unlock()
+			return nil, false, err
+		}
+
+		res.waitingFor = call
+		g.mu.Unlock()
+
+		var ctxErr error
+		select {
+		case <-call.done:
+		case <-res.ctx.Done():
+			ctxErr = fmt.Errorf("goldi: aborted waiting for type %q: %w", typeID, res.ctx.Err())
+		}
+
+		g.mu.Lock()
+		res.waitingFor = nil
+		g.mu.Unlock()
+
+		if ctxErr != nil {
+			return nil, false, ctxErr
+		}
+
+		if call.err != nil {
+			return nil, false, call.err
+		}
+
+		return call.instance, true, nil
+	}
+
+	call := &flightCall{
+		key:   key,
+		done:  make(chan struct{}),
+		owner: res,
+		err:   fmt.Errorf("goldi: error while generating type %q: the type factory panicked", typeID),
+	}
+	g.calls[key] = call
+	g.mu.Unlock()
+
+	// release the waiting goroutines even if the type factory panics
+	defer func() {
+		g.mu.Lock()
+		delete(g.calls, key)
+		g.mu.Unlock()
+		close(call.done)
+	}()
+
+	panicErr := call.err
+	for {
+		call.instance, _, call.err = c.generate(typeID, generator, res)
+		if call.err != nil {
+			return nil, false, call.err
+		}
+
+		g.mu.Lock()
+		if call.stale == false {
+			c.typeCache.Store(typeID, call.instance)
+
+			// dependencies are always stored before their dependents so this is a valid dependency order
+			c.mu.Lock()
+			c.creationOrder = append(c.creationOrder, typeID)
+			c.mu.Unlock()
+			g.mu.Unlock()
+
+			return call.instance, true, nil
+		}
+
+		// the type has been invalidated while it was generated so the instance might depend on evicted instances
+		stale := call.instance
+		call.stale, call.instance, call.err = false, nil, panicErr
+		g.mu.Unlock()
+
+		// the stale instance has never been visible to anyone else so it is closed right away; errors are ignored
+		// because the caller gets the new instance
+		_ = c.closeInstance(context.WithoutCancel(res.ctx), typeID, stale)
+	}
+}
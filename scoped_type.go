@@ -0,0 +1,62 @@
+package goldi
+
+import "fmt"
+
+// A Scope defines the lifetime of the instances a Container generates for a type.
+type Scope string
+
+const (
+	// Singleton is the default scope. A singleton type is generated once and the same instance is
+	// returned on every following request to the container.
+	Singleton Scope = "singleton"
+
+	// Prototype types are never cached. The container generates a new instance on each request.
+	Prototype Scope = "prototype"
+
+	// Scoped types are cached once per scope (see Container.NewScope).
+	// When requested from a container that is no scope they behave exactly like singletons.
+	Scoped Scope = "scoped"
+)
+
+type scopedType struct {
+	TypeFactory
+	scope Scope
+}
+
+// NewScopedType creates a new TypeFactory that decorates a given TypeFactory and defines the Scope
+// of the instances it generates. Types that are not wrapped with NewScopedType are singletons.
+//
+// NewScopedType will return an invalid type when embeddedType is nil or the scope is unknown.
+//
+// Goldigen yaml syntax example:
+//
+//	my_type:
+//	    package: github.com/fgrosse/foobar
+//	    factory: NewParser
+//	    scope:   prototype
+func NewScopedType(embeddedType TypeFactory, scope Scope) TypeFactory {
+	if embeddedType == nil {
+		return newInvalidType(fmt.Errorf("refusing to create a new ScopedType with nil as embedded type"))
+	}
+
+	switch scope {
+	case Singleton, Prototype, Scoped:
+		return &scopedType{TypeFactory: embeddedType, scope: scope}
+	default:
+		return newInvalidType(fmt.Errorf("can not create a new ScopedType with unknown scope %q", scope))
+	}
+}
+
+func (t *scopedType) unwrap() TypeFactory {
+	return t.TypeFactory
+}
+
+// ScopeOf returns the Scope of the given TypeFactory.
+// Types that have not been created with NewScopedType are always singletons.
+func ScopeOf(t TypeFactory) Scope {
+	if scoped, ok := findEmbedded[*scopedType](t); ok {
+		return scoped.scope
+	}
+
+	return Singleton
+}
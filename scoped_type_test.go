@@ -0,0 +1,76 @@
+package goldi_test
+
+import (
+	"fmt"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/tarokamikaze/goldi"
+)
+
+func ExampleNewScopedType() {
+	container := goldi.NewContainer(goldi.NewTypeRegistry(), map[string]interface{}{})
+
+	// prototypes are generated anew each time they are requested
+	container.Register("parser", goldi.NewScopedType(goldi.NewType(NewMockType), goldi.Prototype))
+
+	p1 := container.MustGet("parser")
+	p2 := container.MustGet("parser")
+	fmt.Println(p1 == p2)
+	// Output:
+	// false
+}
+
+var _ = Describe("scopedType", func() {
+	It("should implement the TypeFactory interface", func() {
+		var factory goldi.TypeFactory
+		factory = goldi.NewScopedType(goldi.NewType(NewMockType), goldi.Prototype)
+		// if this compiles the test passes (next expectation only to make compiler happy)
+		Expect(factory).NotTo(BeNil())
+	})
+
+	Describe("NewScopedType()", func() {
+		It("should return an invalid type if the embedded type is nil", func() {
+			Expect(goldi.IsValid(goldi.NewScopedType(nil, goldi.Prototype))).To(BeFalse())
+		})
+
+		It("should return an invalid type if the scope is unknown", func() {
+			t := goldi.NewScopedType(goldi.NewType(NewMockType), "request")
+			Expect(goldi.IsValid(t)).To(BeFalse())
+			Expect(t).To(MatchError(`can not create a new ScopedType with unknown scope "request"`))
+		})
+	})
+
+	Describe("Arguments()", func() {
+		It("should return the arguments of the embedded type", func() {
+			t := goldi.NewScopedType(goldi.NewType(NewMockTypeWithArgs, "%foo%", "@bar"), goldi.Prototype)
+			Expect(t.Arguments()).To(Equal([]interface{}{"%foo%", "@bar"}))
+		})
+	})
+
+	Describe("Generate()", func() {
+		It("should generate the embedded type", func() {
+			container := goldi.NewContainer(goldi.NewTypeRegistry(), map[string]interface{}{})
+			t := goldi.NewScopedType(goldi.NewType(NewMockTypeWithArgs, "foo", true), goldi.Prototype)
+
+			result, err := t.Generate(container.Resolver)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result).To(Equal(&MockType{StringParameter: "foo", BoolParameter: true}))
+		})
+	})
+
+	Describe("ScopeOf()", func() {
+		It("should return the singleton scope for types that have not been scoped", func() {
+			Expect(goldi.ScopeOf(goldi.NewType(NewMockType))).To(Equal(goldi.Singleton))
+		})
+
+		It("should return the scope of scoped types", func() {
+			Expect(goldi.ScopeOf(goldi.NewScopedType(goldi.NewType(NewMockType), goldi.Prototype))).To(Equal(goldi.Prototype))
+		})
+
+		It("should find the scope of embedded types", func() {
+			scoped := goldi.NewScopedType(goldi.NewStructType(Foo{}), goldi.Prototype)
+			Expect(goldi.ScopeOf(goldi.NewConfiguredType(scoped, "configurator", "Configure"))).To(Equal(goldi.Prototype))
+		})
+	})
+})
@@ -0,0 +1,77 @@
+package goldi
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+	"unicode"
+)
+
+type shutdownType struct {
+	TypeFactory
+	shutdownMethod string
+}
+
+// NewShutdownType creates a new TypeFactory that decorates a given TypeFactory and defines which method
+// of the generated instance should be called when the container is closed (see Container.Close).
+// Use this if your type needs to be shut down but does not implement io.Closer or Close(context.Context) error.
+//
+// The shutdown method may optionally accept a context.Context and return an error.
+// NewShutdownType will return an invalid type when embeddedType is nil or the method is empty or not exported.
+func NewShutdownType(embeddedType TypeFactory, shutdownMethod string) TypeFactory {
+	if embeddedType == nil {
+		return newInvalidType(fmt.Errorf("refusing to create a new ShutdownType with nil as embedded type"))
+	}
+
+	shutdownMethod = strings.TrimSpace(shutdownMethod)
+	if shutdownMethod == "" || unicode.IsLower(rune(shutdownMethod[0])) {
+		return newInvalidType(fmt.Errorf("can not use unexported or empty shutdown method %q", shutdownMethod))
+	}
+
+	return &shutdownType{TypeFactory: embeddedType, shutdownMethod: shutdownMethod}
+}
+
+func (t *shutdownType) unwrap() TypeFactory {
+	return t.TypeFactory
+}
+
+func (t *shutdownType) shutdown(ctx context.Context, instance interface{}) error {
+	method := reflect.ValueOf(instance).MethodByName(t.shutdownMethod)
+	if method.IsValid() == false {
+		return fmt.Errorf("the shutdown method %q does not exist on %T", t.shutdownMethod, instance)
+	}
+
+	var args []reflect.Value
+	switch methodType := method.Type(); {
+	case methodType.NumIn() == 0:
+	case methodType.NumIn() == 1 && methodType.In(0) == contextType:
+		args = []reflect.Value{reflect.ValueOf(ctx)}
+	default:
+		return fmt.Errorf("the shutdown method %q of %T must either accept no arguments or a context.Context", t.shutdownMethod, instance)
+	}
+
+	result := method.Call(args)
+	if len(result) > 0 {
+		return errorResult(result[len(result)-1])
+	}
+
+	return nil
+}
+
+// errorResult returns the given result of a method call if it is a non nil error.
+// Results of error types that can not be nil (e.g. structs) are always returned.
+func errorResult(result reflect.Value) error {
+	if result.Type().Implements(errorType) == false {
+		return nil
+	}
+
+	switch result.Kind() {
+	case reflect.Interface, reflect.Ptr, reflect.Map, reflect.Slice, reflect.Func, reflect.Chan:
+		if result.IsNil() {
+			return nil
+		}
+	}
+
+	return result.Interface().(error)
+}
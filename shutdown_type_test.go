@@ -0,0 +1,105 @@
+package goldi_test
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/tarokamikaze/goldi"
+)
+
+type ContextStoppable struct {
+	Ctx context.Context
+}
+
+func (s *ContextStoppable) Stop(ctx context.Context) error {
+	s.Ctx = ctx
+	return nil
+}
+
+// ShutdownError is an error implementation that can not be nil
+type ShutdownError struct {
+	Message string
+}
+
+func (e ShutdownError) Error() string {
+	return e.Message
+}
+
+// StructErrorStoppable returns a non-nillable error from its shutdown methods
+type StructErrorStoppable struct{}
+
+func (s *StructErrorStoppable) Shutdown() ShutdownError {
+	return ShutdownError{Message: "shutdown failed"}
+}
+
+func (s *StructErrorStoppable) ShutdownPointer() *ShutdownError {
+	return nil
+}
+
+var _ = Describe("shutdownType", func() {
+	It("should implement the TypeFactory interface", func() {
+		var factory goldi.TypeFactory
+		factory = goldi.NewShutdownType(goldi.NewStructType(Stoppable{}), "Shutdown")
+		// if this compiles the test passes (next expectation only to make compiler happy)
+		Expect(factory).NotTo(BeNil())
+	})
+
+	Describe("NewShutdownType()", func() {
+		It("should return an invalid type if the embedded type is nil", func() {
+			Expect(goldi.IsValid(goldi.NewShutdownType(nil, "Shutdown"))).To(BeFalse())
+		})
+
+		It("should return an invalid type if the shutdown method is empty or not exported", func() {
+			Expect(goldi.IsValid(goldi.NewShutdownType(goldi.NewStructType(Stoppable{}), " "))).To(BeFalse())
+			Expect(goldi.IsValid(goldi.NewShutdownType(goldi.NewStructType(Stoppable{}), "shutdown"))).To(BeFalse())
+		})
+	})
+
+	Describe("Arguments()", func() {
+		It("should return the arguments of the embedded type", func() {
+			t := goldi.NewShutdownType(goldi.NewStructType(Stoppable{}, "@log"), "Shutdown")
+			Expect(t.Arguments()).To(Equal([]interface{}{"@log"}))
+		})
+	})
+
+	Describe("shutting down instances", func() {
+		var container *goldi.Container
+
+		BeforeEach(func() {
+			container = goldi.NewContainer(goldi.NewTypeRegistry(), map[string]interface{}{})
+		})
+
+		It("should pass the context if the shutdown method accepts one", func() {
+			container.Register("stoppable", goldi.NewShutdownType(goldi.NewStructType(ContextStoppable{}), "Stop"))
+			stoppable := container.MustGet("stoppable").(*ContextStoppable)
+
+			ctx := context.Background()
+			Expect(container.Close(ctx)).To(Succeed())
+			Expect(stoppable.Ctx).To(BeIdenticalTo(ctx))
+		})
+
+		It("should return an error if the shutdown method does not exist", func() {
+			container.Register("stoppable", goldi.NewShutdownType(goldi.NewStructType(ContextStoppable{}), "DoesNotExist"))
+			container.MustGet("stoppable")
+
+			Expect(container.Close(context.Background())).To(MatchError(
+				`goldi: error while closing type "stoppable": the shutdown method "DoesNotExist" does not exist on *goldi_test.ContextStoppable`,
+			))
+		})
+
+		It("should return errors of types that can not be nil", func() {
+			container.Register("stoppable", goldi.NewShutdownType(goldi.NewStructType(StructErrorStoppable{}), "Shutdown"))
+			container.MustGet("stoppable")
+
+			Expect(container.Close(context.Background())).To(MatchError(`goldi: error while closing type "stoppable": shutdown failed`))
+		})
+
+		It("should ignore nil errors of pointer types", func() {
+			container.Register("stoppable", goldi.NewShutdownType(goldi.NewStructType(StructErrorStoppable{}), "ShutdownPointer"))
+			container.MustGet("stoppable")
+
+			Expect(container.Close(context.Background())).To(Succeed())
+		})
+	})
+})
@@ -3,27 +3,71 @@ package goldi
 import (
 	"fmt"
 	"reflect"
+	"unsafe"
 )
 
 // A structType holds all information that is necessary to create a new instance of some struct type.
 // structType implements the TypeFactory interface.
 type structType struct {
 	structType   reflect.Type
-	structFields []reflect.Value
+	structFields []structField
 }
 
+// A structField is a struct field which is injected using either a struct parameter or the argument in its goldi
+// struct tag.
+type structField struct {
+	index    int
+	argument reflect.Value
+}
+
+// InjectUnexported can be embedded into a struct to allow injecting unexported fields via struct tags or struct
+// parameters.
+//
+//	type Service struct {
+//	    goldi.InjectUnexported
+//	    logger Logger `goldi:"@logger"`
+//	}
+type InjectUnexported struct{}
+
+var (
+	injectUnexportedType = reflect.TypeOf(InjectUnexported{})
+	namedFieldsType      = reflect.TypeOf(map[string]interface{}{})
+)
+
 // NewStructType creates a TypeFactory that can be used to create a new instance of some struct type.
 //
+// The structParameters are assigned to the fields of structT in the order in which the fields are declared.
+// Alternatively a single map[string]interface{} can be given which maps the field names to their arguments.
+// This way adding or reordering fields does not break the type. The map is only treated as positional argument
+// if it can be assigned to the first field of structT.
+//
 // This function will return an invalid type if:
 //   - structT is no struct or pointer to a struct,
 //   - the number of given structParameters exceed the number of field of structT
 //   - the structParameters types do not match the fields of structT
+//   - a named field does not exist
+//   - a goldi struct tag contains no parameter or type reference
+//   - an unexported field should be injected but the struct does not embed InjectUnexported
+//
+// Fields can also be injected using struct tags which contain a parameter or type reference:
+//
+//	type Service struct {
+//	    Logger  Logger        `goldi:"@logger"`
+//	    Timeout time.Duration `goldi:"%timeout%"`
+//	    Metrics *Metrics      `goldi:"@?metrics"`
+//	}
+//
+// The tags are resolved just like the structParameters. If a field is set by a structParameter, its tag is ignored.
+// Unexported fields can only be injected if the struct embeds InjectUnexported.
 //
 // Goldigen yaml syntax example:
 //
 //	logger:
 //	    package: github.com/fgrosse/foobar
 //	    type:    MyType
+//	    fields:
+//	        Level:  "%log_level%"
+//	        Output: "@log_writer"
 func NewStructType(structT interface{}, structParameters ...interface{}) TypeFactory {
 	if structT == nil {
 		return newInvalidType(fmt.Errorf("the given struct is nil"))
@@ -51,62 +95,154 @@ func newTypeFromStruct(generatedType reflect.Type, parameters []interface{}) Typ
 
 	// Use cached reflection operations
 	cache := GetGlobalReflectionCache()
-	args := make([]reflect.Value, len(parameters))
-	for i, argument := range parameters {
-		// TODO: check argument types
-		args[i] = cache.GetValue(argument)
+	arguments := map[int]reflect.Value{}
+	if namedFields, isNamed := structNamedFields(generatedType, parameters); isNamed {
+		for name, argument := range namedFields {
+			field, fieldExists := generatedType.FieldByName(name)
+			if fieldExists == false || len(field.Index) != 1 {
+				return newInvalidType(fmt.Errorf("the struct %s has no field %q", generatedType.Name(), name))
+			}
+
+			arguments[field.Index[0]] = cache.GetValue(argument)
+		}
+	} else {
+		for i, argument := range parameters {
+			arguments[i] = cache.GetValue(argument)
+		}
+	}
+
+	injectsUnexported := false
+	for i := 0; i < generatedType.NumField(); i++ {
+		if field := generatedType.Field(i); field.Anonymous && field.Type == injectUnexportedType {
+			injectsUnexported = true
+		}
+	}
+
+	var fields []structField
+	for i := 0; i < generatedType.NumField(); i++ {
+		field := generatedType.Field(i)
+		argument, isSet := arguments[i]
+		if isSet == false {
+			tag, hasTag := field.Tag.Lookup("goldi")
+			if hasTag == false {
+				continue
+			}
+
+			if IsParameterOrTypeReference(tag) == false {
+				return newInvalidType(fmt.Errorf("the goldi tag %q of field %s.%s is no parameter or type reference", tag, generatedType.Name(), field.Name))
+			}
+
+			argument = reflect.ValueOf(tag)
+		}
+
+		if field.IsExported() == false && injectsUnexported == false {
+			return newInvalidType(fmt.Errorf("can not inject the unexported field %s.%s (embed goldi.InjectUnexported to allow this)", generatedType.Name(), field.Name))
+		}
+
+		argument, err := checkStructArgument(generatedType, field, argument)
+		if err != nil {
+			return newInvalidType(err)
+		}
+
+		fields = append(fields, structField{index: i, argument: argument})
 	}
 
 	return &structType{
 		structType:   generatedType,
-		structFields: args,
+		structFields: fields,
 	}
 }
 
-// Arguments returns all struct parameters from NewStructType
+// structNamedFields returns the field names and arguments if the parameters consist of a single
+// map[string]interface{} which can not be assigned to the first field of the struct.
+func structNamedFields(generatedType reflect.Type, parameters []interface{}) (map[string]interface{}, bool) {
+	if len(parameters) != 1 {
+		return nil, false
+	}
+
+	namedFields, isMap := parameters[0].(map[string]interface{})
+	if isMap == false || namedFieldsType.AssignableTo(generatedType.Field(0).Type) {
+		return nil, false
+	}
+
+	return namedFields, true
+}
+
+// checkStructArgument returns an error if the given argument can not be assigned to the field.
+// Parameters and type references can only be checked when the type is generated.
+func checkStructArgument(generatedType reflect.Type, field reflect.StructField, argument reflect.Value) (reflect.Value, error) {
+	if argument.IsValid() == false {
+		switch field.Type.Kind() {
+		case reflect.Chan, reflect.Func, reflect.Interface, reflect.Map, reflect.Ptr, reflect.Slice:
+			return reflect.Zero(field.Type), nil
+		default:
+			return argument, fmt.Errorf("can not use nil as field %s of struct type %s", field.Name, generatedType.Name())
+		}
+	}
+
+	if argument.Kind() == reflect.String && isDynamicArgument(argument.String()) {
+		return argument, nil
+	}
+
+	if argument.Type().AssignableTo(field.Type) == false {
+		return argument, fmt.Errorf("the argument %v (type %v) can not be used as field %s (type %v) of struct type %s",
+			argument.Interface(), argument.Type(), field.Name, field.Type, generatedType.Name(),
+		)
+	}
+
+	return argument, nil
+}
+
+// Arguments returns all struct parameters from NewStructType and the arguments of all tagged fields
+// in the order in which the fields are declared.
 func (t *structType) Arguments() []interface{} {
 	args := make([]interface{}, len(t.structFields))
-	for i, argument := range t.structFields {
-		args[i] = argument.Interface()
+	for i, field := range t.structFields {
+		args[i] = field.argument.Interface()
 	}
 	return args
 }
 
 // Generate will instantiate a new instance of the according type.
 func (t *structType) Generate(parameterResolver *ParameterResolver) (interface{}, error) {
-	args, err := t.generateTypeFields(parameterResolver)
-	if err != nil {
-		return nil, err
-	}
-
 	newStructInstance := reflect.New(t.structType)
-	for i := 0; i < len(args); i++ {
-		newStructInstance.Elem().Field(i).Set(args[i])
+	for _, field := range t.structFields {
+		value, err := t.resolveField(parameterResolver, field)
+		if err != nil {
+			return nil, err
+		}
+
+		structField := newStructInstance.Elem().Field(field.index)
+		if structField.CanSet() == false {
+			// unexported fields can only be set if the struct embeds InjectUnexported (checked in NewStructType)
+			structField = reflect.NewAt(structField.Type(), unsafe.Pointer(structField.UnsafeAddr())).Elem()
+		}
+
+		structField.Set(value)
 	}
 
 	return newStructInstance.Interface(), nil
 }
 
-func (t *structType) generateTypeFields(parameterResolver *ParameterResolver) ([]reflect.Value, error) {
-	// Pre-allocate with known size for better performance
-	args := make([]reflect.Value, len(t.structFields))
-	var err error
-
-	for i, argument := range t.structFields {
-		expectedArgument := t.structType.Field(i).Type
-		args[i], err = parameterResolver.Resolve(argument, expectedArgument)
+func (t *structType) resolveField(parameterResolver *ParameterResolver, field structField) (reflect.Value, error) {
+	expectedType := t.structType.Field(field.index).Type
+	value, err := parameterResolver.Resolve(field.argument, expectedType)
+	switch errorType := err.(type) {
+	case nil:
+	case TypeReferenceError:
+		return reflect.Value{}, t.invalidReferencedTypeErr(errorType.TypeID, errorType.TypeInstance, field.index)
+	default:
+		return reflect.Value{}, withArgumentIndex(err, field.index)
+	}
 
-		switch errorType := err.(type) {
-		case nil:
-			continue
-		case TypeReferenceError:
-			return nil, t.invalidReferencedTypeErr(errorType.TypeID, errorType.TypeInstance, i)
-		default:
-			return nil, err
-		}
+	// parameters which are not configured are returned as is
+	if value.Type().AssignableTo(expectedType) == false {
+		return reflect.Value{}, fmt.Errorf("the value of %q (type %v) can not be used as field %s of struct type %v",
+			field.argument.Interface(), value.Type(), t.structType.Field(field.index).Name, t.structType,
+		)
 	}
 
-	return args, nil
+	return value, nil
 }
 
 func (t *structType) invalidReferencedTypeErr(typeID string, typeInstance interface{}, i int) error {
@@ -26,6 +26,24 @@ func ExampleNewStructType() {
 	// foo_3: *goldi_test.Foo
 }
 
+// TaggedService is injected using goldi struct tags
+type TaggedService struct {
+	Mock      *MockType `goldi:"@mock"`
+	Name      string    `goldi:"%name%"`
+	Metrics   *Foo      `goldi:"@?metrics"`
+	Untouched string
+}
+
+// UnexportedTaggedService opts in to the injection of unexported fields
+type UnexportedTaggedService struct {
+	goldi.InjectUnexported
+	mock *MockType `goldi:"@mock"`
+}
+
+// UnexportedTaggedServiceWithoutOptIn has a tagged unexported field but does not embed goldi.InjectUnexported
+type UnexportedTaggedServiceWithoutOptIn struct {
+	mock *MockType `goldi:"@mock"`
+}
 
 var _ = Describe("structType", func() {
 	It("should implement the TypeFactory interface", func() {
@@ -66,6 +84,73 @@ var _ = Describe("structType", func() {
 			Expect(goldi.IsValid(t)).To(BeFalse())
 			Expect(t).To(MatchError("the struct MockType has only 2 fields but 3 arguments where provided"))
 		})
+
+		It("should return an invalid type if an argument does not match the field type", func() {
+			t := goldi.NewStructType(&MockType{}, 42, true)
+			Expect(goldi.IsValid(t)).To(BeFalse())
+			Expect(t).To(MatchError("the argument 42 (type int) can not be used as field StringParameter (type string) of struct type MockType"))
+		})
+
+		It("should return an invalid type if nil is given for a field that can not be nil", func() {
+			t := goldi.NewStructType(&MockType{}, nil)
+			Expect(goldi.IsValid(t)).To(BeFalse())
+			Expect(t).To(MatchError("can not use nil as field StringParameter of struct type MockType"))
+		})
+
+		Context("with named fields", func() {
+			It("should create the type", func() {
+				t := goldi.NewStructType(&MockType{}, map[string]interface{}{"BoolParameter": true})
+				Expect(goldi.IsValid(t)).To(BeTrue())
+			})
+
+			It("should return an invalid type if a field does not exist", func() {
+				t := goldi.NewStructType(&MockType{}, map[string]interface{}{"DoesNotExist": true})
+				Expect(goldi.IsValid(t)).To(BeFalse())
+				Expect(t).To(MatchError(`the struct MockType has no field "DoesNotExist"`))
+			})
+
+			It("should return an invalid type if a field is unexported", func() {
+				type Unexported struct {
+					name string
+				}
+
+				t := goldi.NewStructType(Unexported{}, map[string]interface{}{"name": "foo"})
+				Expect(goldi.IsValid(t)).To(BeFalse())
+				Expect(t).To(MatchError("can not inject the unexported field Unexported.name (embed goldi.InjectUnexported to allow this)"))
+			})
+
+			It("should return an invalid type if an argument does not match the field type", func() {
+				t := goldi.NewStructType(&MockType{}, map[string]interface{}{"BoolParameter": "yes"})
+				Expect(goldi.IsValid(t)).To(BeFalse())
+				Expect(t).To(MatchError("the argument yes (type string) can not be used as field BoolParameter (type bool) of struct type MockType"))
+			})
+
+			It("should use the map as positional argument if it can be assigned to the first field", func() {
+				type Config struct {
+					Values map[string]interface{}
+				}
+
+				t := goldi.NewStructType(Config{}, map[string]interface{}{"Values": true})
+				Expect(goldi.IsValid(t)).To(BeTrue())
+				Expect(t.Arguments()).To(Equal([]interface{}{map[string]interface{}{"Values": true}}))
+			})
+		})
+
+		It("should return an invalid type if a goldi tag contains no parameter or type reference", func() {
+			type InvalidTag struct {
+				Name string `goldi:"name"`
+			}
+
+			t := goldi.NewStructType(InvalidTag{})
+			Expect(goldi.IsValid(t)).To(BeFalse())
+			Expect(t).To(MatchError(`the goldi tag "name" of field InvalidTag.Name is no parameter or type reference`))
+		})
+
+		It("should return an invalid type if an unexported field is tagged without embedding goldi.InjectUnexported", func() {
+			t := goldi.NewStructType(UnexportedTaggedServiceWithoutOptIn{})
+			Expect(goldi.IsValid(t)).To(BeFalse())
+			Expect(t).To(MatchError("can not inject the unexported field UnexportedTaggedServiceWithoutOptIn.mock (embed goldi.InjectUnexported to allow this)"))
+		})
 	})
 
 	Describe("Arguments()", func() {
@@ -74,6 +159,16 @@ var _ = Describe("structType", func() {
 			typeDef := goldi.NewStructType(MockType{}, args...)
 			Expect(typeDef.Arguments()).To(Equal(args))
 		})
+
+		It("should return the named arguments in the order of the struct fields", func() {
+			typeDef := goldi.NewStructType(MockType{}, map[string]interface{}{"BoolParameter": true, "StringParameter": "foo"})
+			Expect(typeDef.Arguments()).To(Equal([]interface{}{"foo", true}))
+		})
+
+		It("should also return the arguments of all tagged fields", func() {
+			typeDef := goldi.NewStructType(TaggedService{}, "@other_mock")
+			Expect(typeDef.Arguments()).To(Equal([]interface{}{"@other_mock", "%name%", "@?metrics"}))
+		})
 	})
 
 	Describe("Generate()", func() {
@@ -148,6 +243,18 @@ var _ = Describe("structType", func() {
 				Expect(generatedMock.BoolParameter).To(Equal(true))
 			})
 
+			It("should assign named arguments to their fields", func() {
+				typeDef := goldi.NewStructType(&MockType{}, map[string]interface{}{"BoolParameter": "%param2%", "StringParameter": "foo"})
+				config["param2"] = true
+
+				generatedType, err := typeDef.Generate(resolver)
+				Expect(err).NotTo(HaveOccurred())
+
+				generatedMock := generatedType.(*MockType)
+				Expect(generatedMock.StringParameter).To(Equal("foo"))
+				Expect(generatedMock.BoolParameter).To(BeTrue())
+			})
+
 			Context("when a type reference is given", func() {
 				Context("and its type matches the struct field type", func() {
 					It("should generate the type", func() {
@@ -174,5 +281,73 @@ var _ = Describe("structType", func() {
 				})
 			})
 		})
+
+		Context("with goldi struct tags", func() {
+			BeforeEach(func() {
+				container.RegisterType("mock", NewMockType)
+				config["name"] = "tagged"
+			})
+
+			It("should inject the tagged fields", func() {
+				typeDef := goldi.NewStructType(TaggedService{})
+
+				generatedType, err := typeDef.Generate(resolver)
+				Expect(err).NotTo(HaveOccurred())
+
+				service := generatedType.(*TaggedService)
+				Expect(service.Mock).To(BeIdenticalTo(container.MustGet("mock")))
+				Expect(service.Name).To(Equal("tagged"))
+				Expect(service.Metrics).To(BeNil())
+				Expect(service.Untouched).To(BeEmpty())
+			})
+
+			It("should inject optional types if they are defined", func() {
+				container.RegisterType("metrics", NewFoo)
+				typeDef := goldi.NewStructType(TaggedService{})
+
+				generatedType, err := typeDef.Generate(resolver)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(generatedType.(*TaggedService).Metrics).To(BeIdenticalTo(container.MustGet("metrics")))
+			})
+
+			It("should prefer positional arguments over struct tags", func() {
+				container.RegisterType("other_mock", NewMockType)
+				typeDef := goldi.NewStructType(TaggedService{}, "@other_mock")
+
+				generatedType, err := typeDef.Generate(resolver)
+				Expect(err).NotTo(HaveOccurred())
+
+				service := generatedType.(*TaggedService)
+				Expect(service.Mock).To(BeIdenticalTo(container.MustGet("other_mock")))
+				Expect(service.Name).To(Equal("tagged"))
+			})
+
+			It("should inject unexported fields if the struct embeds goldi.InjectUnexported", func() {
+				typeDef := goldi.NewStructType(UnexportedTaggedService{})
+
+				generatedType, err := typeDef.Generate(resolver)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(generatedType.(*UnexportedTaggedService).mock).To(BeIdenticalTo(container.MustGet("mock")))
+			})
+
+			It("should return an error if a referenced type does not match the field type", func() {
+				container.RegisterType("mock", NewFoo)
+				typeDef := goldi.NewStructType(TaggedService{})
+
+				_, err := typeDef.Generate(resolver)
+				Expect(err).To(MatchError(`the referenced type "@mock" (type *goldi_test.Foo) can not be used as field 1 for struct type goldi_test.TaggedService`))
+			})
+
+			It("should return an error if a parameter is not configured and the field is no string", func() {
+				type Timeout struct {
+					Seconds int `goldi:"%timeout%"`
+				}
+
+				typeDef := goldi.NewStructType(Timeout{})
+
+				_, err := typeDef.Generate(resolver)
+				Expect(err).To(MatchError(`the value of "%timeout%" (type string) can not be used as field Seconds of struct type goldi_test.Timeout`))
+			})
+		})
 	})
 })
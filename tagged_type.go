@@ -0,0 +1,132 @@
+package goldi
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+)
+
+// A Tag marks a type as member of a group of types that can be retrieved together (see Container.GetTagged).
+// Types with a higher Priority come first. The Attributes can hold arbitrary additional information.
+type Tag struct {
+	Name       string
+	Priority   int
+	Attributes map[string]interface{}
+}
+
+// A TaggedType describes a type that has been tagged with a specific tag.
+type TaggedType struct {
+	TypeID string
+	Tag    Tag
+}
+
+type taggedType struct {
+	TypeFactory
+	tags []Tag
+}
+
+// NewTaggedType creates a new TypeFactory that decorates a given TypeFactory and adds the given tags to it.
+// All types that share a tag can be retrieved via Container.GetTagged or injected into a slice using the
+// tagged reference syntax (e.g. "!tagged http.middleware").
+//
+// NewTaggedType will return an invalid type when embeddedType is nil, no tags are given or any tag has no name.
+//
+// Goldigen yaml syntax example:
+//
+//	logging_middleware:
+//	    package: github.com/fgrosse/foobar
+//	    factory: NewLoggingMiddleware
+//	    tags:
+//	        - http.middleware
+//	        - { name: health_check, priority: 10, endpoint: /health }
+func NewTaggedType(embeddedType TypeFactory, tags ...Tag) TypeFactory {
+	if embeddedType == nil {
+		return newInvalidType(fmt.Errorf("refusing to create a new TaggedType with nil as embedded type"))
+	}
+
+	if len(tags) == 0 {
+		return newInvalidType(fmt.Errorf("can not create a new TaggedType without any tags"))
+	}
+
+	for _, tag := range tags {
+		if strings.TrimSpace(tag.Name) == "" {
+			return newInvalidType(fmt.Errorf("can not create a new TaggedType with an empty tag name"))
+		}
+	}
+
+	return &taggedType{TypeFactory: embeddedType, tags: tags}
+}
+
+func (t *taggedType) unwrap() TypeFactory {
+	return t.TypeFactory
+}
+
+// TagsOf returns all tags of the given TypeFactory.
+func TagsOf(t TypeFactory) []Tag {
+	var tags []Tag
+	for t != nil {
+		if tagged, isTagged := t.(*taggedType); isTagged {
+			tags = append(tags, tagged.tags...)
+		}
+
+		wrapped, isWrapped := t.(wrappedTypeFactory)
+		if isWrapped == false {
+			break
+		}
+
+		t = wrapped.unwrap()
+	}
+
+	return tags
+}
+
+// FindTaggedTypes returns all types of this container and its parents that have been tagged with the given tag name.
+// The types are ordered by the priority of their tag (highest first) and then by their type ID.
+func (c *Container) FindTaggedTypes(tagName string) []TaggedType {
+	var result []TaggedType
+	seen := map[string]bool{}
+	for container := c; container != nil; container = container.parent {
+		container.readTypes(func(types TypeRegistry) {
+			for typeID, factory := range types {
+				if seen[typeID] {
+					// the type has been overwritten by a scope
+					continue
+				}
+
+				seen[typeID] = true
+				for _, tag := range TagsOf(factory) {
+					if tag.Name == tagName {
+						result = append(result, TaggedType{TypeID: typeID, Tag: tag})
+						break
+					}
+				}
+			}
+		})
+	}
+
+	slices.SortFunc(result, func(a, b TaggedType) int {
+		if a.Tag.Priority != b.Tag.Priority {
+			return cmp.Compare(b.Tag.Priority, a.Tag.Priority)
+		}
+
+		return strings.Compare(a.TypeID, b.TypeID)
+	})
+
+	return result
+}
+
+// GetTagged retrieves the instances of all types that have been tagged with the given tag name.
+// The instances are ordered by the priority of their tag (highest first) and then by their type ID.
+// If any of the types can not be generated GetTagged returns an error.
+func (c *Container) GetTagged(tagName string) ([]interface{}, error) {
+	resolver := &ParameterResolver{Container: c, resolution: newResolution(context.Background())}
+	instances, err := resolver.resolveTaggedReference(taggedReferencePrefix+tagName, reflect.TypeOf([]interface{}{}))
+	if err != nil {
+		return nil, err
+	}
+
+	return instances.Interface().([]interface{}), nil
+}
@@ -0,0 +1,235 @@
+package goldi_test
+
+import (
+	"fmt"
+	"math"
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/tarokamikaze/goldi"
+)
+
+// Middleware is a test interface for tagged types
+type Middleware interface {
+	Name() string
+}
+
+type NamedMiddleware struct {
+	name string
+}
+
+func NewNamedMiddleware(name string) *NamedMiddleware {
+	return &NamedMiddleware{name}
+}
+
+func (m *NamedMiddleware) Name() string {
+	return m.name
+}
+
+// Router is a test type that gets all middlewares injected
+type Router struct {
+	Middlewares []Middleware
+}
+
+func NewRouter(middlewares []Middleware) *Router {
+	return &Router{middlewares}
+}
+
+func NewVariadicRouter(middlewares ...Middleware) *Router {
+	return &Router{middlewares}
+}
+
+func (r *Router) MiddlewareNames() string {
+	names := make([]string, len(r.Middlewares))
+	for i, m := range r.Middlewares {
+		names[i] = m.Name()
+	}
+	return strings.Join(names, ", ")
+}
+
+func ExampleNewTaggedType() {
+	container := goldi.NewContainer(goldi.NewTypeRegistry(), map[string]interface{}{})
+
+	container.Register("logging", goldi.NewTaggedType(goldi.NewType(NewNamedMiddleware, "logging"), goldi.Tag{Name: "http.middleware"}))
+	container.Register("auth", goldi.NewTaggedType(goldi.NewType(NewNamedMiddleware, "auth"), goldi.Tag{Name: "http.middleware", Priority: 10}))
+	container.Register("router", goldi.NewType(NewRouter, "!tagged http.middleware"))
+
+	router := container.MustGet("router").(*Router)
+	fmt.Println(router.MiddlewareNames())
+	// Output:
+	// auth, logging
+}
+
+var _ = Describe("taggedType", func() {
+	It("should implement the TypeFactory interface", func() {
+		var factory goldi.TypeFactory
+		factory = goldi.NewTaggedType(goldi.NewType(NewMockType), goldi.Tag{Name: "foo"})
+		// if this compiles the test passes (next expectation only to make compiler happy)
+		Expect(factory).NotTo(BeNil())
+	})
+
+	Describe("NewTaggedType()", func() {
+		It("should return an invalid type if the embedded type is nil", func() {
+			Expect(goldi.IsValid(goldi.NewTaggedType(nil, goldi.Tag{Name: "foo"}))).To(BeFalse())
+		})
+
+		It("should return an invalid type if no tags are given", func() {
+			Expect(goldi.IsValid(goldi.NewTaggedType(goldi.NewType(NewMockType)))).To(BeFalse())
+		})
+
+		It("should return an invalid type if a tag has no name", func() {
+			t := goldi.NewTaggedType(goldi.NewType(NewMockType), goldi.Tag{Name: "foo"}, goldi.Tag{Name: " "})
+			Expect(goldi.IsValid(t)).To(BeFalse())
+			Expect(t).To(MatchError("can not create a new TaggedType with an empty tag name"))
+		})
+	})
+
+	Describe("Arguments()", func() {
+		It("should return the arguments of the embedded type", func() {
+			t := goldi.NewTaggedType(goldi.NewType(NewMockTypeWithArgs, "%foo%", "@bar"), goldi.Tag{Name: "foo"})
+			Expect(t.Arguments()).To(Equal([]interface{}{"%foo%", "@bar"}))
+		})
+	})
+
+	Describe("TagsOf()", func() {
+		It("should return nil for types without tags", func() {
+			Expect(goldi.TagsOf(goldi.NewType(NewMockType))).To(BeNil())
+		})
+
+		It("should return the tags of all wrapped types", func() {
+			foo := goldi.Tag{Name: "foo", Attributes: map[string]interface{}{"bar": "baz"}}
+			bar := goldi.Tag{Name: "bar", Priority: 10}
+			t := goldi.NewTaggedType(goldi.NewType(NewMockType), foo)
+			t = goldi.NewScopedType(goldi.NewTaggedType(t, bar), goldi.Prototype)
+
+			Expect(goldi.TagsOf(t)).To(Equal([]goldi.Tag{bar, foo}))
+		})
+	})
+
+	Describe("tagged types in the container", func() {
+		var (
+			registry  goldi.TypeRegistry
+			container *goldi.Container
+		)
+
+		middleware := func(name string, priority int) goldi.TypeFactory {
+			return goldi.NewTaggedType(goldi.NewType(NewNamedMiddleware, name), goldi.Tag{Name: "http.middleware", Priority: priority})
+		}
+
+		BeforeEach(func() {
+			registry = goldi.NewTypeRegistry()
+			container = goldi.NewContainer(registry, map[string]interface{}{})
+
+			registry.Register("b", middleware("b", 0))
+			registry.Register("a", middleware("a", 0))
+			registry.Register("c", middleware("c", 10))
+			registry.Register("d", middleware("d", -5))
+			registry.Register("other", goldi.NewTaggedType(goldi.NewType(NewNamedMiddleware, "other"), goldi.Tag{Name: "health_check"}))
+		})
+
+		Describe("FindTaggedTypes()", func() {
+			It("should return the tagged types ordered by priority and type ID", func() {
+				var typeIDs []string
+				for _, t := range container.FindTaggedTypes("http.middleware") {
+					typeIDs = append(typeIDs, t.TypeID)
+				}
+
+				Expect(typeIDs).To(Equal([]string{"c", "a", "b", "d"}))
+			})
+
+			It("should order the tagged types by priority if the priorities are far apart", func() {
+				registry.Register("min", middleware("min", math.MinInt))
+				registry.Register("max", middleware("max", math.MaxInt))
+
+				var typeIDs []string
+				for _, t := range container.FindTaggedTypes("http.middleware") {
+					typeIDs = append(typeIDs, t.TypeID)
+				}
+
+				Expect(typeIDs).To(Equal([]string{"max", "c", "a", "b", "d", "min"}))
+			})
+
+			It("should return the matching tag", func() {
+				Expect(container.FindTaggedTypes("health_check")).To(Equal([]goldi.TaggedType{
+					{TypeID: "other", Tag: goldi.Tag{Name: "health_check"}},
+				}))
+			})
+
+			It("should return nothing if no type has the tag", func() {
+				Expect(container.FindTaggedTypes("unknown")).To(BeEmpty())
+			})
+
+			It("should include the types of parent containers", func() {
+				scope := container.NewScope()
+				scope.Register("e", middleware("e", 0))
+				scope.Register("a", goldi.NewType(NewNamedMiddleware, "a")) // not tagged anymore
+
+				var typeIDs []string
+				for _, t := range scope.FindTaggedTypes("http.middleware") {
+					typeIDs = append(typeIDs, t.TypeID)
+				}
+
+				Expect(typeIDs).To(Equal([]string{"c", "b", "e", "d"}))
+			})
+		})
+
+		Describe("GetTagged()", func() {
+			It("should return the instances of all tagged types", func() {
+				instances, err := container.GetTagged("http.middleware")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(instances).To(HaveLen(4))
+				Expect(instances[0]).To(BeIdenticalTo(container.MustGet("c")))
+				Expect(instances[3]).To(BeIdenticalTo(container.MustGet("d")))
+			})
+
+			It("should return an empty result if no type has the tag", func() {
+				Expect(container.GetTagged("unknown")).To(BeEmpty())
+			})
+
+			It("should return an error if a type can not be generated", func() {
+				registry.Register("broken", goldi.NewTaggedType(goldi.NewType(NewTypeForServiceInjection, "@unknown"), goldi.Tag{Name: "http.middleware"}))
+
+				_, err := container.GetTagged("http.middleware")
+				Expect(err).To(MatchError(ContainSubstring(`goldi: error while generating type "broken"`)))
+			})
+		})
+
+		Describe("tagged references", func() {
+			It("should inject the tagged types as slice", func() {
+				registry.RegisterType("router", NewRouter, "!tagged http.middleware")
+				Expect(container.MustGet("router").(*Router).MiddlewareNames()).To(Equal("c, a, b, d"))
+			})
+
+			It("should expand the tagged types into variadic arguments", func() {
+				registry.RegisterType("router", NewVariadicRouter, "@other", "!tagged http.middleware")
+				Expect(container.MustGet("router").(*Router).MiddlewareNames()).To(Equal("other, c, a, b, d"))
+			})
+
+			It("should inject the tagged types into struct fields", func() {
+				registry.RegisterType("router", &Router{}, "!tagged health_check")
+				Expect(container.MustGet("router").(*Router).MiddlewareNames()).To(Equal("other"))
+			})
+
+			It("should inject an empty slice if no type has the tag", func() {
+				registry.RegisterType("router", NewRouter, "!tagged unknown")
+				Expect(container.MustGet("router").(*Router).Middlewares).To(BeEmpty())
+			})
+
+			It("should return an error if the tagged types are not assignable to the slice", func() {
+				registry.Register("mock", goldi.NewTaggedType(goldi.NewType(NewMockType), goldi.Tag{Name: "http.middleware"}))
+				registry.RegisterType("router", NewRouter, "!tagged http.middleware")
+
+				_, err := container.Get("router")
+				Expect(err).To(MatchError(ContainSubstring(`the referenced type "@mock" (type *goldi_test.MockType) can not be passed as argument 1`)))
+			})
+
+			It("should return an error if the expected type is no slice", func() {
+				registry.RegisterType("mock", NewMockTypeWithArgs, "!tagged http.middleware", true)
+
+				_, err := container.Get("mock")
+				Expect(err).To(MatchError(ContainSubstring(`the tagged reference "!tagged http.middleware" can only be injected into a slice but the expected type is string`)))
+			})
+		})
+	})
+})
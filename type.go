@@ -1,6 +1,7 @@
 package goldi
 
 import (
+	"context"
 	"fmt"
 	"reflect"
 	"runtime"
@@ -13,13 +14,16 @@ type typeFactory struct {
 	factory          reflect.Value
 	factoryType      reflect.Type
 	factoryArguments []reflect.Value
+	returnsError     bool // whether the factory returns an error as second return parameter
+	injectsContext   bool // whether the context of the resolution is passed as first argument to the factory
 }
 
 // NewType creates a new TypeFactory.
 //
 // This function will return an invalid type if:
 //   - the factoryFunction is nil or no function,
-//   - the factoryFunction returns zero or more than one parameter
+//   - the factoryFunction returns zero or more than two parameters
+//   - the factoryFunction returns two parameters but the second one is not an error
 //   - the factoryFunctions return parameter is no pointer, interface  or function type.
 //   - the number of given factoryParameters does not match the number of arguments of the factoryFunction
 //
@@ -31,6 +35,12 @@ type typeFactory struct {
 //	    args:
 //	        - "Hello World"
 //	        - true
+//
+// The factoryFunction may return an error as second return parameter (e.g. func NewDB(dsn string) (*DB, error)).
+// If the returned error is not nil it is returned by Generate and thus by Container.Get.
+//
+// If the first argument of the factoryFunction is a context.Context you can omit it from the factoryParameters.
+// In this case the context that has been passed to Container.GetContext is injected automatically.
 func NewType(factoryFunction interface{}, factoryParameters ...interface{}) TypeFactory {
 	if factoryFunction == nil {
 		return newInvalidType(fmt.Errorf("the given factoryFunction is nil"))
@@ -47,7 +57,12 @@ func NewType(factoryFunction interface{}, factoryParameters ...interface{}) Type
 }
 
 func newTypeFromFactoryFunction(function interface{}, factoryType reflect.Type, parameters []interface{}) TypeFactory {
-	if factoryType.NumOut() != 1 {
+	returnsError := factoryType.NumOut() == 2 && factoryType.Out(1) == errorType
+	if factoryType.NumOut() != 1 && returnsError == false {
+		if factoryType.NumOut() == 2 {
+			return newInvalidType(fmt.Errorf("the second return parameter must be an error (got %v)", factoryType.Out(1)))
+		}
+
 		return newInvalidType(fmt.Errorf("invalid number of return parameters: %d", factoryType.NumOut()))
 	}
 
@@ -58,25 +73,33 @@ func newTypeFromFactoryFunction(function interface{}, factoryType reflect.Type,
 		return newInvalidType(fmt.Errorf("return parameter type %v is not supported for dependency injection", kindOfGeneratedType))
 	}
 
+	injectsContext := factoryInjectsContext(factoryType, parameters)
+	numIn := factoryType.NumIn()
+	if injectsContext {
+		numIn--
+	}
+
 	if factoryType.IsVariadic() {
-		if factoryType.NumIn() > len(parameters) {
-			return newInvalidType(fmt.Errorf("invalid number of input parameters for variadic function: got %d but expected at least %d", len(parameters), factoryType.NumIn()))
+		if numIn > len(parameters) {
+			return newInvalidType(fmt.Errorf("invalid number of input parameters for variadic function: got %d but expected at least %d", len(parameters), numIn))
 		}
 	} else {
-		if factoryType.NumIn() != len(parameters) {
-			return newInvalidType(fmt.Errorf("invalid number of input parameters: got %d but expected %d", len(parameters), factoryType.NumIn()))
+		if numIn != len(parameters) {
+			return newInvalidType(fmt.Errorf("invalid number of input parameters: got %d but expected %d", len(parameters), numIn))
 		}
 	}
 
 	// Use cached reflection operations
 	cache := GetGlobalReflectionCache()
 	t := &typeFactory{
-		factory:     cache.GetValue(function),
-		factoryType: factoryType,
+		factory:        cache.GetValue(function),
+		factoryType:    factoryType,
+		returnsError:   returnsError,
+		injectsContext: injectsContext,
 	}
 
 	var err error
-	t.factoryArguments, err = buildFactoryCallArguments(factoryType, parameters)
+	t.factoryArguments, err = buildFactoryCallArguments(factoryType, parameters, t.contextOffset())
 	if err != nil {
 		return newInvalidType(err)
 	}
@@ -84,7 +107,31 @@ func newTypeFromFactoryFunction(function interface{}, factoryType reflect.Type,
 	return t
 }
 
-func buildFactoryCallArguments(t reflect.Type, allParameters []interface{}) ([]reflect.Value, error) {
+// factoryInjectsContext returns true if the first argument of the factory function is a context.Context
+// that has not been given explicitly as parameter. For variadic functions the context is considered to be given
+// if the first parameter is a context.Context or a type reference.
+func factoryInjectsContext(t reflect.Type, parameters []interface{}) bool {
+	if t.NumIn() == 0 || t.In(0) != contextType {
+		return false
+	}
+
+	if t.IsVariadic() == false {
+		return len(parameters) == t.NumIn()-1
+	}
+
+	if len(parameters) == 0 {
+		return true
+	}
+
+	if _, isContext := parameters[0].(context.Context); isContext {
+		return false
+	}
+
+	stringParameter, isString := parameters[0].(string)
+	return isString == false || IsTypeReference(stringParameter) == false
+}
+
+func buildFactoryCallArguments(t reflect.Type, allParameters []interface{}, offset int) ([]reflect.Value, error) {
 	actualNumberOfArgs := t.NumIn()
 
 	// Pre-allocate with known size for better performance
@@ -92,19 +139,19 @@ func buildFactoryCallArguments(t reflect.Type, allParameters []interface{}) ([]r
 
 	for i, argument := range allParameters {
 		var expectedArgumentType reflect.Type
-		if t.IsVariadic() && i >= actualNumberOfArgs-1 {
+		if t.IsVariadic() && i+offset >= actualNumberOfArgs-1 {
 			// variadic argument
 			expectedArgumentType = t.In(actualNumberOfArgs - 1).Elem()
 		} else {
 			// regular argument
-			expectedArgumentType = t.In(i)
+			expectedArgumentType = t.In(i + offset)
 		}
 
 		// Use cached reflection operations
 		cache := GetGlobalReflectionCache()
 		args[i] = cache.GetValue(argument)
 		if args[i].Kind() != expectedArgumentType.Kind() {
-			if stringArg, isString := argument.(string); isString && !IsParameterOrTypeReference(stringArg) {
+			if stringArg, isString := argument.(string); isString && !isDynamicArgument(stringArg) {
 				return nil, fmt.Errorf("input argument %d is of type %s but needs to be a %s", i+1, args[i].Kind(), expectedArgumentType.Kind())
 			}
 		}
@@ -129,6 +176,11 @@ func (t *typeFactory) Generate(resolver *ParameterResolver) (interface{}, error)
 		return nil, err
 	}
 
+	// the context might have been canceled while the arguments have been resolved
+	if err := resolver.Context().Err(); err != nil {
+		return nil, err
+	}
+
 	var result []reflect.Value
 	if t.factoryType.IsVariadic() {
 		result = t.factory.CallSlice(args)
@@ -136,29 +188,47 @@ func (t *typeFactory) Generate(resolver *ParameterResolver) (interface{}, error)
 		result = t.factory.Call(args)
 	}
 
-	// we check the number of return arguments in NewType so there is always exactly one result
+	// we check the return arguments in NewType so the second result can only be an error
+	if t.returnsError && result[1].IsNil() == false {
+		return nil, result[1].Interface().(error)
+	}
+
 	return result[0].Interface(), nil
 }
 
+// contextOffset returns the index of the first factory argument that is not the injected context.
+func (t *typeFactory) contextOffset() int {
+	if t.injectsContext {
+		return 1
+	}
+
+	return 0
+}
+
 func (t *typeFactory) generateFactoryArguments(resolver *ParameterResolver) ([]reflect.Value, error) {
 	if t.factoryType.IsVariadic() {
 		return t.generateVariadicFactoryArguments(resolver)
 	}
 
 	// Pre-allocate with known size for better performance
-	args := make([]reflect.Value, len(t.factoryArguments))
+	offset := t.contextOffset()
+	args := make([]reflect.Value, len(t.factoryArguments)+offset)
 	var err error
 
+	if t.injectsContext {
+		args[0] = reflect.ValueOf(resolver.Context())
+	}
+
 	for i, argument := range t.factoryArguments {
-		args[i], err = resolver.Resolve(argument, t.factoryType.In(i))
+		args[i+offset], err = resolver.Resolve(argument, t.factoryType.In(i+offset))
 
 		switch errorType := err.(type) {
 		case nil:
 			continue
 		case TypeReferenceError:
-			return nil, t.invalidReferencedTypeErr(errorType.TypeID, errorType.TypeInstance, i)
+			return nil, t.invalidReferencedTypeErr(errorType.TypeID, errorType.TypeInstance, i+offset)
 		default:
-			return nil, err
+			return nil, withArgumentIndex(err, i+offset)
 		}
 	}
 
@@ -171,36 +241,51 @@ func (t *typeFactory) generateVariadicFactoryArguments(resolver *ParameterResolv
 	args := make([]reflect.Value, numIn)
 	var err error
 
+	offset := t.contextOffset()
+	if t.injectsContext {
+		args[0] = reflect.ValueOf(resolver.Context())
+	}
+
 	actualNumberOfArgs := t.factoryType.NumIn()
-	for i, argument := range t.factoryArguments[:actualNumberOfArgs-1] {
-		args[i], err = resolver.Resolve(argument, t.factoryType.In(i))
+	for i, argument := range t.factoryArguments[:actualNumberOfArgs-1-offset] {
+		args[i+offset], err = resolver.Resolve(argument, t.factoryType.In(i+offset))
 
 		switch errorType := err.(type) {
 		case nil:
 			continue
 		case TypeReferenceError:
-			return nil, t.invalidReferencedTypeErr(errorType.TypeID, errorType.TypeInstance, i)
+			return nil, t.invalidReferencedTypeErr(errorType.TypeID, errorType.TypeInstance, i+offset)
 		default:
-			return nil, err
+			return nil, withArgumentIndex(err, i+offset)
 		}
 	}
 
-	n := len(t.factoryArguments) - actualNumberOfArgs + 1
+	n := len(t.factoryArguments) - actualNumberOfArgs + 1 + offset
 	variadicType := t.factoryType.In(actualNumberOfArgs - 1)
-	variadicSlice := reflect.MakeSlice(variadicType, n, n)
-	expectedType := variadicType.Elem()
-	for i, argument := range t.factoryArguments[actualNumberOfArgs-1:] {
+	variadicSlice := reflect.MakeSlice(variadicType, 0, n)
+	for i, argument := range t.factoryArguments[actualNumberOfArgs-1-offset:] {
+		expectedType := variadicType.Elem()
+		isTaggedReference := argument.Kind() == reflect.String && IsTaggedReference(argument.String())
+		if isTaggedReference {
+			// tagged references are expanded into all tagged instances
+			expectedType = variadicType
+		}
+
 		resolvedArgument, err := resolver.Resolve(argument, expectedType)
 		if err != nil {
 			switch errorType := err.(type) {
 			case TypeReferenceError:
 				return nil, t.invalidReferencedTypeErr(errorType.TypeID, errorType.TypeInstance, i)
 			default:
-				return nil, err
+				return nil, withArgumentIndex(err, actualNumberOfArgs-1+i)
 			}
 		}
 
-		variadicSlice.Index(i).Set(resolvedArgument)
+		if isTaggedReference {
+			variadicSlice = reflect.AppendSlice(variadicSlice, resolvedArgument)
+		} else {
+			variadicSlice = reflect.Append(variadicSlice, resolvedArgument)
+		}
 	}
 
 	args[actualNumberOfArgs-1] = variadicSlice
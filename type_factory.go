@@ -11,3 +11,28 @@ type TypeFactory interface {
 	// Generate will instantiate a new instance of the according type or return an error.
 	Generate(parameterResolver *ParameterResolver) (interface{}, error)
 }
+
+// wrappedTypeFactory is implemented by all type factories that decorate another TypeFactory
+// (e.g. NewConfiguredType or NewScopedType).
+type wrappedTypeFactory interface {
+	unwrap() TypeFactory
+}
+
+// findEmbedded walks the chain of decorating type factories starting at t and returns the first one of type T.
+func findEmbedded[T TypeFactory](t TypeFactory) (T, bool) {
+	for t != nil {
+		if match, ok := t.(T); ok {
+			return match, true
+		}
+
+		wrapped, isWrapped := t.(wrappedTypeFactory)
+		if isWrapped == false {
+			break
+		}
+
+		t = wrapped.unwrap()
+	}
+
+	var zero T
+	return zero, false
+}
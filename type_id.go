@@ -1,6 +1,9 @@
 package goldi
 
-import "strings"
+import (
+	"regexp"
+	"strings"
+)
 
 // TypeID represents a parsed type identifier and associated meta data.
 type TypeID struct {
@@ -54,9 +57,15 @@ func (t *TypeID) String() string {
 }
 
 // IsParameterOrTypeReference is a utility function that returns whether the given string represents a parameter or a reference to a type.
-// See IsParameter and IsTypeReference for further details
+// See IsParameter, IsTypeReference and IsTaggedReference for further details
 func IsParameterOrTypeReference(p string) bool {
-	return IsParameter(p) || IsTypeReference(p)
+	return IsParameter(p) || IsTypeReference(p) || IsTaggedReference(p)
+}
+
+// isDynamicArgument returns whether the given string argument is resolved when the type is generated.
+// The type of such an argument can only be checked against the expected type once it has been resolved.
+func isDynamicArgument(p string) bool {
+	return IsParameterOrTypeReference(p) || isInterpolated(p)
 }
 
 // IsParameter returns whether the given type ID represents a parameter.
@@ -70,6 +79,73 @@ func IsParameter(p string) bool {
 	return p[0] == '%' && p[len(p)-1] == '%'
 }
 
+// parameterPlaceholder matches parameters that are embedded in a string as well as the %% escape sequence.
+// Parameter names start with a letter or an underscore, consist of letters, digits, underscores, dots and dashes and
+// end with a letter, digit or underscore.
+// They can be followed by the arguments of a processor in parentheses and by a non-empty inline default
+// (e.g. "%env(int:PORT):8080%"). This way percent-encoded strings like "a%20b%2Fc" and format strings like
+// "%H:%M:%S" are not mistaken for parameters.
+var parameterPlaceholder = regexp.MustCompile(`%%|%[A-Za-z_](?:[A-Za-z0-9_.\-]*[A-Za-z0-9_])?(?:\([^%()]*\))?(?::[^%\s]+)?%`)
+
+// ParameterNames returns the names of all parameters that are used in the given string.
+// Parameters can either make up the whole string (see IsParameter) or be embedded into it like in
+// "postgres://%db.user%@%db.host%/app". Use %% to add a literal percent sign to a string that contains parameters.
+func ParameterNames(p string) []string {
+	var names []string
+	for _, placeholder := range parameterPlaceholder.FindAllString(p, -1) {
+		if placeholder != "%%" {
+			names = append(names, placeholder[1:len(placeholder)-1])
+		}
+	}
+
+	if len(names) == 0 && IsParameter(p) {
+		return []string{p[1 : len(p)-1]}
+	}
+
+	return names
+}
+
+// SplitParameterDefault splits a parameter name like "http.port:8080" into the name of the parameter and its inline
+// default value which is used if the parameter has not been defined. Colons within parentheses are part of the name
+// so processors can be used in environment variable parameters (e.g. "env(int:PORT):8080").
+//
+// Defaults must not be empty so literal strings like "%H:%M:%S" are not mistaken for parameters with defaults.
+// Use a quoted empty string ("prefix:''" or `prefix:""`) to default to an empty value.
+func SplitParameterDefault(parameterName string) (name, defaultValue string, hasDefault bool) {
+	depth := 0
+	for i, c := range parameterName {
+		switch {
+		case c == '(':
+			depth++
+		case c == ')':
+			depth--
+		case c == ':' && depth == 0:
+			switch defaultValue = parameterName[i+1:]; defaultValue {
+			case "":
+				return parameterName, "", false
+			case `""`, "''":
+				return parameterName[:i], "", true
+			default:
+				return parameterName[:i], defaultValue, true
+			}
+		}
+	}
+
+	return parameterName, "", false
+}
+
+// isInterpolated returns whether the given string contains embedded parameters (see ParameterNames).
+// Strings that consist of exactly one parameter are not interpolated since they resolve to the configured value
+// instead of its string representation.
+func isInterpolated(p string) bool {
+	names := ParameterNames(p)
+	if len(names) == 0 {
+		return false
+	}
+
+	return len(names) > 1 || p != "%"+names[0]+"%"
+}
+
 // IsTypeReference returns whether the given string represents a reference to a type.
 // A goldi type reference is recognized by the leading @ sign.
 // Example: @foobar
@@ -80,3 +156,12 @@ func IsTypeReference(p string) bool {
 
 	return p[0] == '@'
 }
+
+const taggedReferencePrefix = "!tagged "
+
+// IsTaggedReference returns whether the given string represents a reference to all types with a specific tag.
+// A tagged reference is recognized by the leading "!tagged " followed by the name of the tag.
+// Example: !tagged http.middleware
+func IsTaggedReference(p string) bool {
+	return strings.HasPrefix(p, taggedReferencePrefix) && strings.TrimSpace(p[len(taggedReferencePrefix):]) != ""
+}
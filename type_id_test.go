@@ -30,3 +30,76 @@ var _ = Describe("TypeID", func() {
 		})
 	})
 })
+
+var _ = Describe("SplitParameterDefault", func() {
+	It("should split the name and the default value", func() {
+		name, defaultValue, hasDefault := goldi.SplitParameterDefault("http.port:8080")
+		Expect(name).To(Equal("http.port"))
+		Expect(defaultValue).To(Equal("8080"))
+		Expect(hasDefault).To(BeTrue())
+	})
+
+	It("should allow quoted empty defaults", func() {
+		name, defaultValue, hasDefault := goldi.SplitParameterDefault(`prefix:""`)
+		Expect(name).To(Equal("prefix"))
+		Expect(defaultValue).To(BeEmpty())
+		Expect(hasDefault).To(BeTrue())
+
+		name, defaultValue, hasDefault = goldi.SplitParameterDefault("prefix:''")
+		Expect(name).To(Equal("prefix"))
+		Expect(defaultValue).To(BeEmpty())
+		Expect(hasDefault).To(BeTrue())
+	})
+
+	It("should not treat an empty default as default", func() {
+		name, _, hasDefault := goldi.SplitParameterDefault("prefix:")
+		Expect(name).To(Equal("prefix:"))
+		Expect(hasDefault).To(BeFalse())
+	})
+
+	It("should ignore colons within parentheses", func() {
+		name, _, hasDefault := goldi.SplitParameterDefault("env(int:PORT)")
+		Expect(name).To(Equal("env(int:PORT)"))
+		Expect(hasDefault).To(BeFalse())
+
+		name, defaultValue, hasDefault := goldi.SplitParameterDefault("env(int:PORT):8080")
+		Expect(name).To(Equal("env(int:PORT)"))
+		Expect(defaultValue).To(Equal("8080"))
+		Expect(hasDefault).To(BeTrue())
+	})
+})
+
+var _ = Describe("ParameterNames", func() {
+	It("should return the name of a parameter", func() {
+		Expect(goldi.ParameterNames("%foo%")).To(Equal([]string{"foo"}))
+	})
+
+	It("should return the names of all embedded parameters", func() {
+		Expect(goldi.ParameterNames("postgres://%db.user%@%db.host%/app")).To(Equal([]string{"db.user", "db.host"}))
+	})
+
+	It("should ignore escaped percent signs", func() {
+		Expect(goldi.ParameterNames("100%% of %foo%")).To(Equal([]string{"foo"}))
+		Expect(goldi.ParameterNames("100%%")).To(BeEmpty())
+	})
+
+	It("should return nothing for strings without parameters", func() {
+		Expect(goldi.ParameterNames("foo")).To(BeEmpty())
+		Expect(goldi.ParameterNames("50% off")).To(BeEmpty())
+	})
+
+	It("should return the names of parameters with processors and defaults", func() {
+		Expect(goldi.ParameterNames("http://%env(HOST):localhost%:%http.port:8080%")).To(Equal([]string{"env(HOST):localhost", "http.port:8080"}))
+	})
+
+	It("should not treat printf and strftime format strings as parameters", func() {
+		Expect(goldi.ParameterNames("%H:%M:%S")).To(BeEmpty())
+		Expect(goldi.ParameterNames("%s:%d")).To(BeEmpty())
+		Expect(goldi.ParameterNames("%Y-%m-%d %H:%M")).To(BeEmpty())
+	})
+
+	It("should not treat percent-encoded strings as parameters", func() {
+		Expect(goldi.ParameterNames("a%20b%2Fc")).To(BeEmpty())
+		Expect(goldi.ParameterNames("https://example.com/search?q=a%20b%2Fc&lang=%lang%")).To(Equal([]string{"lang"}))
+	})
+})
@@ -6,11 +6,17 @@ import (
 	"maps"
 	"reflect"
 	"slices"
+	"sync/atomic"
 )
 
 // The TypeRegistry is effectively a map of typeID strings to TypeFactory
 type TypeRegistry map[string]TypeFactory
 
+// registryReplacements counts how often the methods of any TypeRegistry replaced an existing type. Indexes that are
+// derived from a registry (see Container.decorate) are rebuilt when this counter or the size of the registry changes.
+// Writing into the map directly (e.g. registry["foo"] = factory) is not detected.
+var registryReplacements atomic.Uint64
+
 // NewTypeRegistry creates a new empty TypeRegistry
 func NewTypeRegistry() TypeRegistry {
 	return TypeRegistry{}
@@ -44,12 +50,23 @@ func (r TypeRegistry) RegisterType(typeID string, factory interface{}, arguments
 // It is perfectly legal to call Register multiple times with the same typeID.
 // In this case you overwrite existing type definitions with new once
 func (r TypeRegistry) Register(typeID string, typeDef TypeFactory) {
+	if _, isReplaced := r[typeID]; isReplaced {
+		registryReplacements.Add(1)
+	}
+
 	r[typeID] = typeDef
 }
 
 // RegisterAll will register all given type factories under the mapped type ID
 // It uses maps.Copy for efficient bulk registration
 func (r TypeRegistry) RegisterAll(factories map[string]TypeFactory) {
+	for typeID := range factories {
+		if _, isReplaced := r[typeID]; isReplaced {
+			registryReplacements.Add(1)
+			break
+		}
+	}
+
 	maps.Copy(r, factories)
 }
 
@@ -60,6 +77,13 @@ func (r TypeRegistry) InjectInstance(typeID string, instance interface{}) {
 	r.Register(typeID, factory)
 }
 
+// InjectOwnedInstance behaves like InjectInstance but lets the container take ownership of the instance.
+// Owned instances are closed by Container.Close while instances injected via InjectInstance are skipped.
+func (r TypeRegistry) InjectOwnedInstance(typeID string, instance interface{}) {
+	factory := NewOwnedInstanceType(instance)
+	r.Register(typeID, factory)
+}
+
 // All returns an iterator over all registered type IDs and their factories
 // This uses Go 1.24's range over func feature for memory-efficient iteration
 func (r TypeRegistry) All() iter.Seq2[string, TypeFactory] {
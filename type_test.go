@@ -1,6 +1,7 @@
 package goldi_test
 
 import (
+	"errors"
 	"fmt"
 
 	. "github.com/onsi/ginkgo/v2"
@@ -41,6 +42,28 @@ var _ = Describe("type", func() {
 
 			It("should return an invalid type if the generator has more than one output parameter", func() {
 				Expect(goldi.IsValid(goldi.NewType(func() (*MockType, *MockType) { return nil, nil }))).To(BeFalse())
+				Expect(goldi.IsValid(goldi.NewType(func() (*MockType, error, error) { return nil, nil, nil }))).To(BeFalse())
+			})
+
+			It("should return an invalid type if the second output parameter is no error", func() {
+				t := goldi.NewType(func() (*MockType, bool) { return nil, false })
+				Expect(goldi.IsValid(t)).To(BeFalse())
+				Expect(t).To(MatchError("the second return parameter must be an error (got bool)"))
+			})
+
+			It("should not require the context if the first argument of the generator is a context.Context", func() {
+				t := goldi.NewType(NewContextAwareClient, "localhost")
+				Expect(goldi.IsValid(t)).To(BeTrue())
+				Expect(t.Arguments()).To(Equal([]interface{}{"localhost"}))
+			})
+
+			It("should allow to pass the context explicitly", func() {
+				Expect(goldi.IsValid(goldi.NewType(NewContextAwareClient, "@ctx", "localhost"))).To(BeTrue())
+				Expect(goldi.IsValid(goldi.NewType(NewContextAwareClient))).To(BeFalse())
+			})
+
+			It("should not return an invalid type if the generator returns an error as second output parameter", func() {
+				Expect(goldi.IsValid(goldi.NewType(NewMockTypeOrError, "foo"))).To(BeTrue())
 			})
 
 			It("should allow struct return types for flexibility", func() {
@@ -136,6 +159,33 @@ var _ = Describe("type", func() {
 			})
 		})
 
+		Context("with a factory function that returns an error", func() {
+			It("should generate the type if the error is nil", func() {
+				typeDef := goldi.NewType(NewMockTypeOrError, "foo")
+
+				generatedType, err := typeDef.Generate(resolver)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(generatedType).To(Equal(&MockType{StringParameter: "foo"}))
+			})
+
+			It("should return the error of the factory function", func() {
+				typeDef := goldi.NewType(NewMockTypeOrError, "")
+
+				generatedType, err := typeDef.Generate(resolver)
+				Expect(err).To(BeIdenticalTo(MockTypeErr))
+				Expect(generatedType).To(BeNil())
+			})
+
+			It("should return the error from the container with the type ID", func() {
+				container.Register("db", goldi.NewType(NewMockTypeOrError, ""))
+
+				_, err := container.Get("db")
+				Expect(err).To(MatchError(`goldi: error while generating type "db": the string parameter must not be empty`))
+				Expect(errors.Is(err, MockTypeErr)).To(BeTrue())
+				Expect(container.CollectCachedTypeIDs()).To(BeEmpty())
+			})
+		})
+
 		Context("with one or more factory function arguments", func() {
 			It("should generate the type", func() {
 				typeDef := goldi.NewType(NewMockTypeWithArgs, "foo", true)
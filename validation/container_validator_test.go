@@ -1,6 +1,9 @@
 package validation_test
 
 import (
+	"fmt"
+	"sync"
+
 	"github.com/tarokamikaze/goldi"
 	"github.com/tarokamikaze/goldi/validation"
 	. "github.com/onsi/ginkgo/v2"
@@ -22,6 +25,25 @@ var _ = Describe("ContainerValidator", func() {
 		validator = validation.NewContainerValidator()
 	})
 
+	It("should validate the container while other goroutines register types", func() {
+		config["param"] = true
+		container.Register("injected_type", goldi.NewType(NewMockTypeWithArgs, "hello world", "%param%"))
+
+		var wg sync.WaitGroup
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 10; i++ {
+				container.Register(fmt.Sprintf("type_%d", i), goldi.NewType(NewTypeForServiceInjection, "@injected_type"))
+			}
+		}()
+
+		for i := 0; i < 10; i++ {
+			Expect(validator.Validate(container)).To(Succeed())
+		}
+		wg.Wait()
+	})
+
 	It("should return an error if an invalid type was registered", func() {
 		registry.Register("main_type", goldi.NewFuncReferenceType("not_existent", "type"))
 
@@ -35,6 +57,33 @@ var _ = Describe("ContainerValidator", func() {
 		Expect(validator.Validate(container)).NotTo(Succeed())
 	})
 
+	It("should return an error when a parameter embedded in a string has not been set", func() {
+		config["db.host"] = "localhost"
+		typeDef := goldi.NewType(NewMockTypeWithArgs, "postgres://%db.user%@%db.host%/app", true)
+		registry.Register("main_type", typeDef)
+
+		Expect(validator.Validate(container)).To(MatchError(ContainSubstring(`the parameter "%db.user%" is required by type "main_type" but has not been defined`)))
+	})
+
+	It("should understand nested parameter paths", func() {
+		config["database"] = map[string]interface{}{"user": "goldi"}
+		registry.Register("main_type", goldi.NewType(NewMockTypeWithArgs, "%database.user%", true))
+		Expect(validator.Validate(container)).To(Succeed())
+
+		registry.Register("other_type", goldi.NewType(NewMockTypeWithArgs, "%database.password%", true))
+		Expect(validator.Validate(container)).To(MatchError(ContainSubstring(`the parameter "%database.password%" is required by type "other_type" but has not been defined`)))
+	})
+
+	It("should not require parameters with inline defaults to be defined", func() {
+		registry.Register("main_type", goldi.NewType(NewMockTypeWithArgs, "%greeting:hello%", "%flag:true%"))
+		Expect(validator.Validate(container)).To(Succeed())
+	})
+
+	It("should not require environment variable and file parameters to be defined", func() {
+		registry.Register("main_type", goldi.NewType(NewMockTypeWithArgs, "%env(GOLDI_UNDEFINED_VARIABLE)%", "%file(/does/not/exist)%"))
+		Expect(validator.Validate(container)).To(Succeed())
+	})
+
 	It("should return an error when a dependend type has not been registered", func() {
 		typeDef := goldi.NewType(NewTypeForServiceInjection, "@injected_type")
 		registry.Register("main_type", typeDef)
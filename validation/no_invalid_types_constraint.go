@@ -11,7 +11,7 @@ type NoInvalidTypesConstraint struct{}
 
 // Validate implements the Constraint interface by checking if the given container does not contain invalid types.
 func (c *NoInvalidTypesConstraint) Validate(container *goldi.Container) (err error) {
-	for typeID, typeFactory := range container.TypeRegistry {
+	for typeID, typeFactory := range container.All() {
 		if goldi.IsValid(typeFactory) == false {
 			return fmt.Errorf("type %q is invalid: %s", typeID, typeFactory.(error))
 		}
@@ -11,7 +11,7 @@ type TypeParametersConstraint struct{}
 
 // Validate implements the Constraint interface by checking if all referenced parameters have been defined.
 func (c *TypeParametersConstraint) Validate(container *goldi.Container) (err error) {
-	for typeID, typeFactory := range container.TypeRegistry {
+	for typeID, typeFactory := range container.All() {
 		allArguments := typeFactory.Arguments()
 		if err = c.validateTypeParameters(typeID, container, allArguments); err != nil {
 			return err
@@ -24,7 +24,13 @@ func (c *TypeParametersConstraint) Validate(container *goldi.Container) (err err
 func (c *TypeParametersConstraint) validateTypeParameters(typeID string, container *goldi.Container, allArguments []interface{}) error {
 	typeParameters := c.parameterArguments(allArguments)
 	for _, parameterName := range typeParameters {
-		_, isParameterDefined := container.Config[parameterName]
+		if _, _, hasDefault := goldi.SplitParameterDefault(parameterName); hasDefault || goldi.IsProcessedParameter(parameterName) {
+			// environment variables and files are resolved lazily when the type is generated and
+			// parameters with inline defaults do not need to be defined
+			continue
+		}
+
+		_, isParameterDefined := goldi.LookupParameter(container.Config, parameterName)
 		if isParameterDefined == false {
 			return fmt.Errorf(`the parameter "%%%s%%" is required by type %q but has not been defined`, parameterName, typeID)
 		}
@@ -36,8 +42,8 @@ func (c *TypeParametersConstraint) parameterArguments(allArguments []interface{}
 	parameterArguments := make([]string, 0, len(allArguments))
 	for _, argument := range allArguments {
 		stringArgument, isString := argument.(string)
-		if isString && goldi.IsParameter(stringArgument) {
-			parameterArguments = append(parameterArguments, stringArgument[1:len(stringArgument)-1])
+		if isString && goldi.IsTypeReference(stringArgument) == false {
+			parameterArguments = append(parameterArguments, goldi.ParameterNames(stringArgument)...)
 		}
 	}
 	return parameterArguments
@@ -14,12 +14,13 @@ type TypeReferencesConstraint struct {
 
 // Validate implements the Constraint interface by checking if all referenced types have been defined.
 func (c *TypeReferencesConstraint) Validate(container *goldi.Container) (err error) {
-	for typeID, typeFactory := range container.TypeRegistry {
+	types := container.CollectAll()
+	for typeID, typeFactory := range types {
 		// reset the validation type cache
 		c.checkedTypes = goldi.StringSet{}
 		allArguments := typeFactory.Arguments()
 
-		if err = c.validateTypeReferences(typeID, container, allArguments); err != nil {
+		if err = c.validateTypeReferences(typeID, types, allArguments); err != nil {
 			return err
 		}
 	}
@@ -27,7 +28,7 @@ func (c *TypeReferencesConstraint) Validate(container *goldi.Container) (err err
 	return nil
 }
 
-func (c *TypeReferencesConstraint) validateTypeReferences(typeID string, container *goldi.Container, allArguments []interface{}) error {
+func (c *TypeReferencesConstraint) validateTypeReferences(typeID string, types map[string]goldi.TypeFactory, allArguments []interface{}) error {
 	typeRefParameters := c.typeReferenceArguments(allArguments)
 	for _, referencedTypeID := range typeRefParameters {
 		if c.checkedTypes.Contains(referencedTypeID) {
@@ -35,14 +36,14 @@ func (c *TypeReferencesConstraint) validateTypeReferences(typeID string, contain
 			continue
 		}
 
-		referencedTypeFactory, err := c.checkTypeIsDefined(goldi.NewTypeID(typeID).ID, goldi.NewTypeID(referencedTypeID).ID, container)
+		referencedTypeFactory, err := c.checkTypeIsDefined(goldi.NewTypeID(typeID).ID, goldi.NewTypeID(referencedTypeID).ID, types)
 		if err != nil {
 			return err
 		}
 
 		c.circularDependencyCheckMap = goldi.StringSet{}
 		c.circularDependencyCheckMap.Set(typeID)
-		if err = c.checkCircularDependency(referencedTypeFactory, referencedTypeID, container); err != nil {
+		if err = c.checkCircularDependency(referencedTypeFactory, referencedTypeID, types); err != nil {
 			return err
 		}
 
@@ -55,15 +56,15 @@ func (c *TypeReferencesConstraint) typeReferenceArguments(allArguments []interfa
 	typeRefParameters := make([]string, 0, len(allArguments))
 	for _, argument := range allArguments {
 		stringArgument, isString := argument.(string)
-		if isString && goldi.IsTypeReference(stringArgument) {
+		if isString && goldi.IsTypeReference(stringArgument) && goldi.NewTypeID(stringArgument).ID != goldi.InnerTypeID {
 			typeRefParameters = append(typeRefParameters, stringArgument[1:])
 		}
 	}
 	return typeRefParameters
 }
 
-func (c *TypeReferencesConstraint) checkTypeIsDefined(t, referencedType string, container *goldi.Container) (goldi.TypeFactory, error) {
-	typeDef, isDefined := container.TypeRegistry[referencedType]
+func (c *TypeReferencesConstraint) checkTypeIsDefined(t, referencedType string, types map[string]goldi.TypeFactory) (goldi.TypeFactory, error) {
+	typeDef, isDefined := types[referencedType]
 	if isDefined == false {
 		return nil, fmt.Errorf("type %q references unknown type %q", t, referencedType)
 	}
@@ -71,12 +72,12 @@ func (c *TypeReferencesConstraint) checkTypeIsDefined(t, referencedType string,
 	return typeDef, nil
 }
 
-func (c *TypeReferencesConstraint) checkCircularDependency(typeFactory goldi.TypeFactory, typeID string, container *goldi.Container) error {
+func (c *TypeReferencesConstraint) checkCircularDependency(typeFactory goldi.TypeFactory, typeID string, types map[string]goldi.TypeFactory) error {
 	allArguments := typeFactory.Arguments()
 	typeRefParameters := c.typeReferenceArguments(allArguments)
 
 	for _, referencedTypeID := range typeRefParameters {
-		referencedType, err := c.checkTypeIsDefined(goldi.NewTypeID(typeID).ID, goldi.NewTypeID(referencedTypeID).ID, container)
+		referencedType, err := c.checkTypeIsDefined(goldi.NewTypeID(typeID).ID, goldi.NewTypeID(referencedTypeID).ID, types)
 		if err != nil {
 			// TEST: test this for improved code coverage
 			return nil
@@ -87,7 +88,7 @@ func (c *TypeReferencesConstraint) checkCircularDependency(typeFactory goldi.Typ
 		}
 
 		c.circularDependencyCheckMap.Set(typeID)
-		if err = c.checkCircularDependency(referencedType, referencedTypeID, container); err != nil {
+		if err = c.checkCircularDependency(referencedType, referencedTypeID, types); err != nil {
 			return err
 		}
 	}
@@ -1,6 +1,7 @@
 package goldi_test
 
 import (
+	"errors"
 	"strings"
 	"testing"
 
@@ -57,6 +58,17 @@ func NewMockType() *MockType {
 	return &MockType{}
 }
 
+// MockTypeErr is returned by NewMockTypeOrError if the given string parameter is empty
+var MockTypeErr = errors.New("the string parameter must not be empty")
+
+func NewMockTypeOrError(stringParameter string) (*MockType, error) {
+	if stringParameter == "" {
+		return nil, MockTypeErr
+	}
+
+	return &MockType{StringParameter: stringParameter}, nil
+}
+
 func NewMockTypeWithArgs(stringParameter string, boolParameter bool) *MockType {
 	return &MockType{stringParameter, boolParameter}
 }